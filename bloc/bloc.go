@@ -1,6 +1,13 @@
 package bloc
 
 import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hijgo/go-bloc/clock"
+	err "github.com/hijgo/go-bloc/error"
 	"github.com/hijgo/go-bloc/event"
 	"github.com/hijgo/go-bloc/stream"
 )
@@ -15,11 +22,38 @@ var DefaultMaxHistorySize = 100
 //
 // BD : BloCData Type of data that will be available to function that produces new states, can be used for example
 // to store additional data not originating from events or store event specific data temporally for use later
+//
+// BloCData : The BloCData the BloC was created with, use GetBloCData to receive the BloCData currently used by the BloC
 type BloC[E any, S any, BD any] struct {
 	eventStream     *stream.Stream[event.Event[E]]
 	stateStream     *stream.Stream[S]
 	BloCData        BD
 	mapEventToState func(NewEvent event.Event[E], AdditionalData *BD) S
+	mapWithState    func(CurrentState S, NewEvent event.Event[E], AdditionalData *BD, Emit func(Effect any), AddFollowUp func(FollowUp E, Options ...event.Option)) S
+	core            *core[E, S, BD]
+}
+
+// Holds the values of a BloC that are changed while the BloC is running.
+// Shared by all copies of a BloC, so every copy will see the same state and BloCData.
+type core[E any, S any, BD any] struct {
+	id                  uint64
+	name                string
+	disposed            bool
+	lock                sync.RWMutex
+	state               S
+	hasState            bool
+	bloCData            BD
+	transitionListeners listeners[Transition[E, S, BD]]
+	stateListeners      listeners[S]
+	disposeListeners    listeners[struct{}]
+	expiredListeners    listeners[event.Event[E]]
+	dispatchListeners   listeners[event.Event[E]]
+	eventMiddlewares    []EventMiddleware[E]
+	stateInterceptors   []StateInterceptor[S]
+	stateEqual          func(a S, b S) bool
+	effects             effectDelivery
+	followUps           followUpQueue
+	clock               clock.Clock
 }
 
 // Function that should be called if a new BloC is needed.
@@ -37,28 +71,131 @@ type BloC[E any, S any, BD any] struct {
 // mapEventToState : Function that accepts an Event of Type event.Event[E] and a BD ptr to map the event to a new state
 // of type S. This Function will be called everytime when a new event it added to the event stream
 func CreateBloC[E any, S any, BD any](InitialBloCData BD, mapEventToState func(NewEvent event.Event[E], BloCData *BD) S) BloC[E, S, BD] {
+	return createBloC(InitialBloCData, nil, mapEventToState, func(_ S, NewEvent event.Event[E], BloCData *BD, _ func(any), _ func(E, ...event.Option)) S {
+		return mapEventToState(NewEvent, BloCData)
+	})
+}
+
+// Function that should be called if a new BloC is needed, whose next state depends on its current state.
+// Will populate all necessary fields so the BloC can function properly and then return the new BloC of type E,S,BD.
+//
+// E : Type of events being emitted into the BloC
+//
+// S : Type of states being produced by the BloC from incoming events
+//
+// BD : BloCData Type of data that will be available to function that produces new states
+//
+// InitialBloCData : The initial BloCData struct being used by the bloc
+//
+// InitialState : The state the BloC starts with, before the first event was mapped
+//
+// mapEventToState : Function that accepts the current state, an Event of Type event.Event[E] and a BD ptr to map the
+// event to a new state of type S. This Function will be called everytime when a new event it added to the event stream
+func CreateBloCWithState[E any, S any, BD any](InitialBloCData BD, InitialState S, mapEventToState func(CurrentState S, NewEvent event.Event[E], BloCData *BD) S) BloC[E, S, BD] {
+	return createBloC(InitialBloCData, &InitialState, nil, func(CurrentState S, NewEvent event.Event[E], BloCData *BD, _ func(any), _ func(E, ...event.Option)) S {
+		return mapEventToState(CurrentState, NewEvent, BloCData)
+	})
+}
+
+func createBloC[E any, S any, BD any](InitialBloCData BD, InitialState *S, mapEventToState func(NewEvent event.Event[E], BloCData *BD) S, mapWithState func(CurrentState S, NewEvent event.Event[E], BloCData *BD, Emit func(any), AddFollowUp func(E, ...event.Option)) S) BloC[E, S, BD] {
 	newBloC := BloC[E, S, BD]{
 		BloCData:        InitialBloCData,
 		mapEventToState: mapEventToState,
+		mapWithState:    mapWithState,
+		core: &core[E, S, BD]{
+			id:       atomic.AddUint64(&lastBloCID, 1),
+			bloCData: InitialBloCData,
+		},
+	}
+	if InitialState != nil {
+		newBloC.core.state, newBloC.core.hasState = *InitialState, true
 	}
 	stateStream := stream.CreateStream(DefaultMaxHistorySize, func(NewItem S) {})
 	eventStream := stream.CreateStream(DefaultMaxHistorySize, func(NewEvent event.Event[E]) {
-		stateStream.Add(mapEventToState(NewEvent, &newBloC.BloCData))
+		newBloC.handleEvent(NewEvent)
 	})
+	eventStream.OnExpiredItem = newBloC.handleExpiredEvent
 	newBloC.stateStream = &stateStream
 	newBloC.eventStream = &eventStream
+	notifyLifecycleObservers(&newBloC, LifecycleObserver.OnCreate)
 	return newBloC
 }
 
+// Maps a new event to the next state, stores the state and BloCData and informs all transition listeners.
+// If the state was vetoed by an interceptor or equals the current state, nothing will be emitted.
+// Effects emitted by mapEventToState are delivered after the lock was released, follow-up events are added last.
+func (b *BloC[E, S, BD]) handleEvent(NewEvent event.Event[E]) {
+	c := b.GetClock()
+	startedAt := c.Now()
+
+	effects := make([]any, 0)
+	emitEffect := func(Effect any) { effects = append(effects, Effect) }
+	followUps := make([]followUp[E], 0)
+	addFollowUp := func(FollowUp E, Options ...event.Option) {
+		followUps = append(followUps, followUp[E]{data: FollowUp, options: Options})
+	}
+
+	b.core.lock.Lock()
+	previousState, hadPreviousState := b.core.state, b.core.hasState
+	nextState, emit := b.interceptState(previousState, hadPreviousState, b.mapWithState(previousState, NewEvent, &b.core.bloCData, emitEffect, addFollowUp))
+	effectDelivery := b.core.effects
+	defer func() {
+		if effectDelivery != nil {
+			for _, effect := range effects {
+				effectDelivery.deliver(effect)
+			}
+		}
+		b.addFollowUps(NewEvent, followUps)
+	}()
+	if !emit {
+		b.core.lock.Unlock()
+		return
+	}
+	b.core.state, b.core.hasState = nextState, true
+	transition := Transition[E, S, BD]{
+		Event:            NewEvent,
+		PreviousState:    previousState,
+		HasPreviousState: hadPreviousState,
+		NextState:        nextState,
+		BloCData:         b.core.bloCData,
+		StartedAt:        startedAt,
+		FinishedAt:       c.Now(),
+	}
+	transitionListeners := b.core.transitionListeners.snapshot()
+	stateListeners := b.core.stateListeners.snapshot()
+	b.core.lock.Unlock()
+
+	b.stateStream.Add(nextState)
+	for _, listener := range stateListeners {
+		listener(nextState)
+	}
+	for _, listener := range transitionListeners {
+		listener(transition)
+	}
+}
+
 // Should be called when a new Event should be passed to the event stream.
-// Will result ultimately in a new state.
+// Will result ultimately in a new state. Before the event is passed to the event stream, it will be passed through
+// all event middlewares, see UseEventMiddleware.
 //
 // NewEvent : The event of type E that should be passed to the event stream.
-func (b *BloC[E, S, AD]) AddEvent(NewEvent E) {
-	b.eventStream.Add(event.CreateEvent(NewEvent))
+//
+// Options : Options setting the metadata of the event, for example event.CausedBy to add a follow-up event
+//
+// Will return an error if the BloC was disposed or the event was rejected by a middleware.
+func (b *BloC[E, S, AD]) AddEvent(NewEvent E, Options ...event.Option) error {
+	if b.IsDisposed() {
+		return &err.Error{
+			Context: "Cannot add event, BloC was disposed!",
+			Err:     fmt.Errorf("bloc was disposed"),
+			Code:    err.CodeDisposed,
+		}
+	}
+	return b.dispatch(event.CreateEventAt(NewEvent, b.GetClock().Now(), Options...))
 }
 
 // Start listening to the state stream by calling the function.
+// Only one function can listen to the state stream at a time, use AddStateListener to register additional listeners.
 //
 // OnNewState : Function that must accept a new state of type S
 //
@@ -93,8 +230,74 @@ func (b *BloC[E, S, AD]) StopListenToEventStream() error {
 	return b.eventStream.StopListen()
 }
 
+// Returns a copy of all events currently stored in the history of the event stream, ordered from the oldest to the
+// newest event.
+func (b *BloC[E, S, AD]) GetEventHistory() []event.Event[E] {
+	return b.eventStream.History()
+}
+
+// Returns a copy of all events stored in the history of the event stream, that were created between From and To
+// (both inclusive).
+//
+// From : The earliest creation time of an event that should be returned
+//
+// To : The latest creation time of an event that should be returned
+func (b *BloC[E, S, AD]) GetEventsBetween(From time.Time, To time.Time) []event.Event[E] {
+	return stream.EventsBetween(b.eventStream, From, To)
+}
+
+// Returns a copy of all states currently stored in the history of the state stream, ordered from the oldest to the
+// newest state.
+func (b *BloC[E, S, AD]) GetStateHistory() []S {
+	return b.stateStream.History()
+}
+
+// Changes the capacity of the event and state history of the BloC.
+//
+// MaxHistorySize : The new capacity of the histories, use stream.UnlimitedHistorySize to not limit the number of items
+func (b *BloC[E, S, AD]) SetMaxHistorySize(MaxHistorySize int) {
+	b.eventStream.SetMaxHistorySize(MaxHistorySize)
+	b.stateStream.SetMaxHistorySize(MaxHistorySize)
+}
+
+// Adds a retention policy to the event history, for example stream.MaxEventAge to only keep events of the last 24 hours.
+//
+// Policy : The retention policy that should be applied to the event history
+func (b *BloC[E, S, AD]) AddEventRetentionPolicy(Policy stream.RetentionPolicy[event.Event[E]]) {
+	b.eventStream.AddRetentionPolicy(Policy)
+}
+
+// Adds a retention policy to the state history.
+//
+// Policy : The retention policy that should be applied to the state history
+func (b *BloC[E, S, AD]) AddStateRetentionPolicy(Policy stream.RetentionPolicy[S]) {
+	b.stateStream.AddRetentionPolicy(Policy)
+}
+
 // If the BloC is no longer needed call this function to clear it gracefully
+// Calling Dispose on an already disposed BloC has no effect.
 func (b *BloC[E, S, AD]) Dispose() {
+	b.core.lock.Lock()
+	if b.core.disposed {
+		b.core.lock.Unlock()
+		return
+	}
+	b.core.disposed = true
+	disposeListeners := b.core.disposeListeners.snapshot()
+	b.core.lock.Unlock()
+	b.core.followUps.stop()
+
+	for _, listener := range disposeListeners {
+		listener(struct{}{})
+	}
 	b.stateStream.Dispose()
 	b.eventStream.Dispose()
+	notifyLifecycleObservers(b, LifecycleObserver.OnDispose)
+}
+
+// Returns true if the BloC was disposed, if not returns false.
+func (b *BloC[E, S, AD]) IsDisposed() bool {
+	b.core.lock.RLock()
+	defer b.core.lock.RUnlock()
+	return b.core.disposed
 }
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"testing"
 
+	blocError "github.com/hijgo/go-bloc/error"
 	"github.com/hijgo/go-bloc/event"
 	"github.com/hijgo/go-bloc/stream"
 )
@@ -58,7 +59,9 @@ func TestBloC_StartListenToEventStream(t *testing.T) {
 		wg.Done()
 	}
 
-	b.AddEvent(Event{Data: 1})
+	if err := b.AddEvent(Event{Data: 1}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
 
 	if value != 0 {
 		t.Errorf("Expected value To Be Of Value '%d' Actual '%d'", 0, value)
@@ -69,7 +72,9 @@ func TestBloC_StartListenToEventStream(t *testing.T) {
 	}
 
 	wg.Add(1)
-	b.AddEvent(Event{Data: 2})
+	if err := b.AddEvent(Event{Data: 2}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
 	wg.Wait()
 	if value != 2 {
 		t.Errorf("Expected value To Be Of Value '%d' Actual '%d'", 2, value)
@@ -89,8 +94,8 @@ func TestBloC_StartListenToEventStreamShouldReturnErrorWhenAlreadyListenedTo(t *
 	err = b.StartListenToEventStream()
 	if err == nil {
 		t.Errorf("Expected StartListenToEventStream To Return Error When Already Listened To")
-	} else if wantedErr := errors.New("stream already listened to"); err.Error() != wantedErr.Error() {
-		t.Errorf("Expected StartListenToEventStream To Return Error With Message '%s ' Actual '%s'", wantedErr.Error(), err.Error())
+	} else if wantedErr := blocError.ErrAlreadyListening; !errors.Is(err, wantedErr) {
+		t.Errorf("Expected StartListenToEventStream To Return Error '%s' Actual '%s'", wantedErr.Error(), err.Error())
 	}
 	defer b.Dispose()
 }
@@ -112,7 +117,9 @@ func TestBloC_StopListenToEventStream(t *testing.T) {
 	}
 
 	wg.Add(1)
-	b.AddEvent(Event{Data: 1})
+	if err := b.AddEvent(Event{Data: 1}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
 	wg.Wait()
 	if value != 1 {
 		t.Errorf("Expected check To Be Of Value '%d' Actual '%d'", 1, value)
@@ -123,7 +130,9 @@ func TestBloC_StopListenToEventStream(t *testing.T) {
 		t.Errorf("Unexpected error occured: %s", err.Error())
 	}
 
-	b.AddEvent(Event{Data: 2})
+	if err := b.AddEvent(Event{Data: 2}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
 	if value != 1 {
 		t.Errorf("Expected check To Be Of Value '%d' Actual '%d'", 1, value)
 	}
@@ -137,8 +146,8 @@ func TestBloC_StopListenToEventStreamShouldReturnErrorWhenNotListenedTo(t *testi
 	err := b.StopListenToEventStream()
 	if err == nil {
 		t.Errorf("Expected StopListenToEventStream To Return Error When Already Listened To")
-	} else if wantedErr := errors.New("stream isn't listened to"); err.Error() != wantedErr.Error() {
-		t.Errorf("Expected StopListenToEventStream To Return Error With Message '%s ' Actual '%s'", wantedErr.Error(), err.Error())
+	} else if wantedErr := blocError.ErrNotListening; !errors.Is(err, wantedErr) {
+		t.Errorf("Expected StopListenToEventStream To Return Error '%s' Actual '%s'", wantedErr.Error(), err.Error())
 	}
 	defer b.Dispose()
 
@@ -156,7 +165,9 @@ func TestBloC_ListenOnNewState(t *testing.T) {
 	if err != nil {
 		t.Errorf("Unexpected error occured: %s", err.Error())
 	}
-	b.AddEvent(Event{Data: 1})
+	if err := b.AddEvent(Event{Data: 1}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
 
 	err = b.ListenOnNewState(func(S State) {
 		value = S.State
@@ -171,7 +182,9 @@ func TestBloC_ListenOnNewState(t *testing.T) {
 	}
 
 	wg.Add(1)
-	b.AddEvent(Event{Data: 1})
+	if err := b.AddEvent(Event{Data: 1}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
 	wg.Wait()
 	if value != 2 {
 		t.Errorf("Expected value To Be Of Value '%d' Actual '%d'", 2, value)
@@ -193,7 +206,7 @@ func TestBloC_ListenOnNewStateShouldReturnErrorWhenAlreadyListenedTo(t *testing.
 	err = b.ListenOnNewState(func(state State) {})
 	if err == nil {
 		t.Errorf("Expected ListenOnNewState To Return Error When Already Listened To")
-	} else if wantedErr := errors.New("stream already listened to"); err.Error() != wantedErr.Error() {
+	} else if wantedErr := blocError.ErrAlreadyListening; !errors.Is(err, wantedErr) {
 		t.Errorf("Expected ListenOnNewState To Return Error With Message '%s' Actual '%s'", wantedErr.Error(), err.Error())
 	}
 	defer b.Dispose()
@@ -212,7 +225,9 @@ func TestBloC_StopListenToStateStream(t *testing.T) {
 	}
 
 	wgEvent.Add(1)
-	b.AddEvent(Event{Data: 1})
+	if err := b.AddEvent(Event{Data: 1}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
 	wgEvent.Wait()
 
 	err = b.ListenOnNewState(func(S State) {
@@ -229,7 +244,9 @@ func TestBloC_StopListenToStateStream(t *testing.T) {
 
 	wgState.Add(1)
 	wgEvent.Add(1)
-	b.AddEvent(Event{Data: 1})
+	if err := b.AddEvent(Event{Data: 1}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
 	wgEvent.Wait()
 	wgState.Wait()
 	if value != 2 {
@@ -241,7 +258,9 @@ func TestBloC_StopListenToStateStream(t *testing.T) {
 		t.Errorf("Unexpected error occured: %s", err.Error())
 	}
 	wgEvent.Add(1)
-	b.AddEvent(Event{Data: 2})
+	if err := b.AddEvent(Event{Data: 2}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
 	wgEvent.Wait()
 	if value != 2 {
 		t.Errorf("Expected check To Be Of Value '%d' Actual '%d'", 2, value)
@@ -256,7 +275,7 @@ func TestBloC_StopListenToStateStreamShouldReturnErrorWhenNotListenedTo(t *testi
 	err := b.StopListenToStateStream()
 	if err == nil {
 		t.Errorf("Expected StopListenToStateStream To Return Error When Not Listened To")
-	} else if wantedErr := errors.New("stream isn't listened to"); err.Error() != wantedErr.Error() {
+	} else if wantedErr := blocError.ErrNotListening; !errors.Is(err, wantedErr) {
 		t.Errorf("Expected StopListenToStateStream To Return Error With Message '%s' Actual '%s'", wantedErr.Error(), err.Error())
 	}
 	defer b.Dispose()
@@ -274,9 +293,221 @@ func TestBloC_AddEvent(t *testing.T) {
 	}
 
 	wg.Add(1)
-	b.AddEvent(Event{Data: 1})
+	if err := b.AddEvent(Event{Data: 1}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
 	wg.Wait()
 	if value != 1 {
 		t.Errorf("Expected check To Be Of Value '%d' Actual '%d'", 1, value)
 	}
 }
+
+func TestBloC_GetEventHistoryAndStateHistory(t *testing.T) {
+	var wg sync.WaitGroup
+	bd := BD{}
+	b := CreateBloC(bd, func(E event.Event[Event], BD *BD) State { return State{State: 2 * E.Data.Data} })
+
+	err := b.StartListenToEventStream()
+	if err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	err = b.ListenOnNewState(func(State) { wg.Done() })
+	if err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+
+	wg.Add(2)
+	if err := b.AddEvent(Event{Data: 1}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	if err := b.AddEvent(Event{Data: 2}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	events := b.GetEventHistory()
+	if value := len(events); value != 2 {
+		t.Fatalf("Expected len(GetEventHistory) To Be Of Value '%d' Actual '%d'", 2, value)
+	}
+	if value := events[1].Data.Data; value != 2 {
+		t.Errorf("Expected Event Data To Be Of Value '%d' Actual '%d'", 2, value)
+	}
+
+	states := b.GetStateHistory()
+	if value := len(states); value != 2 {
+		t.Fatalf("Expected len(GetStateHistory) To Be Of Value '%d' Actual '%d'", 2, value)
+	}
+	if value := states[1].State; value != 4 {
+		t.Errorf("Expected State To Be Of Value '%d' Actual '%d'", 4, value)
+	}
+
+	between := b.GetEventsBetween(events[0].Time, events[1].Time)
+	if value := len(between); value != 2 {
+		t.Errorf("Expected len(GetEventsBetween) To Be Of Value '%d' Actual '%d'", 2, value)
+	}
+	defer b.Dispose()
+}
+
+func TestBloC_AddEventRetentionPolicy(t *testing.T) {
+	var wg sync.WaitGroup
+	bd := BD{}
+	b := CreateBloC(bd, func(E event.Event[Event], BD *BD) State { return State{State: E.Data.Data} })
+	b.SetMaxHistorySize(stream.UnlimitedHistorySize)
+	b.AddEventRetentionPolicy(stream.MaxItems[event.Event[Event]](1))
+	b.AddStateRetentionPolicy(stream.MaxItems[State](2))
+
+	err := b.StartListenToEventStream()
+	if err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	err = b.ListenOnNewState(func(State) { wg.Done() })
+	if err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+
+	wg.Add(3)
+	for i := 1; i <= 3; i++ {
+		if err := b.AddEvent(Event{Data: i}); err != nil {
+			t.Errorf("Unexpected error occured: %s", err.Error())
+		}
+	}
+	wg.Wait()
+
+	if value := len(b.GetEventHistory()); value != 1 {
+		t.Errorf("Expected len(GetEventHistory) To Be Of Value '%d' Actual '%d'", 1, value)
+	}
+	if value := len(b.GetStateHistory()); value != 2 {
+		t.Errorf("Expected len(GetStateHistory) To Be Of Value '%d' Actual '%d'", 2, value)
+	}
+	defer b.Dispose()
+}
+
+func TestBloC_AddTransitionListener(t *testing.T) {
+	var wg sync.WaitGroup
+	bd := BD{}
+	b := CreateBloC(bd, func(E event.Event[Event], BD *BD) State {
+		BD.BD += "a"
+		return State{State: E.Data.Data}
+	})
+	transitions := make([]Transition[Event, State, BD], 0)
+	removeListener := b.AddTransitionListener(func(T Transition[Event, State, BD]) {
+		transitions = append(transitions, T)
+		wg.Done()
+	})
+
+	err := b.StartListenToEventStream()
+	if err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+
+	wg.Add(2)
+	if err := b.AddEvent(Event{Data: 1}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	if err := b.AddEvent(Event{Data: 2}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	if value := len(transitions); value != 2 {
+		t.Fatalf("Expected len(transitions) To Be Of Value '%d' Actual '%d'", 2, value)
+	}
+	if value := transitions[1].PreviousState.State; value != 1 {
+		t.Errorf("Expected PreviousState To Be Of Value '%d' Actual '%d'", 1, value)
+	}
+	if value := transitions[1].BloCData.BD; value != "aa" {
+		t.Errorf("Expected BloCData To Be Of Value '%s' Actual '%s'", "aa", value)
+	}
+
+	if state, hasState := b.GetState(); !hasState || state.State != 2 {
+		t.Errorf("Expected GetState To Be Of Value '%d' Actual '%d'", 2, state.State)
+	}
+	if value := b.GetBloCData().BD; value != "aa" {
+		t.Errorf("Expected GetBloCData To Be Of Value '%s' Actual '%s'", "aa", value)
+	}
+
+	removeListener()
+	var wgEvent sync.WaitGroup
+	b.eventStream.OnNewItem = func(NewEvent event.Event[Event]) { b.handleEvent(NewEvent); wgEvent.Done() }
+	wgEvent.Add(1)
+	if err := b.AddEvent(Event{Data: 3}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wgEvent.Wait()
+	if value := len(transitions); value != 2 {
+		t.Errorf("Expected len(transitions) To Be Of Value '%d' Actual '%d'", 2, value)
+	}
+	defer b.Dispose()
+}
+
+func TestBloC_Fork(t *testing.T) {
+	bd := BD{BD: "a"}
+	b := CreateBloC(bd, func(E event.Event[Event], BD *BD) State { return State{State: E.Data.Data} })
+
+	state := State{State: 5}
+	forked := b.Fork(BD{BD: "b"}, &state)
+	if value, hasState := forked.GetState(); !hasState || value != state {
+		t.Errorf("Expected GetState To Be Of Value '%d' Actual '%d'", state.State, value.State)
+	}
+	if value := forked.GetBloCData().BD; value != "b" {
+		t.Errorf("Expected GetBloCData To Be Of Value '%s' Actual '%s'", "b", value)
+	}
+	if _, hasState := b.GetState(); hasState {
+		t.Errorf("Expected Original BloC To Have No State")
+	}
+}
+
+func TestBloC_Seed(t *testing.T) {
+	b := CreateBloC(BD{BD: "a"}, func(E event.Event[Event], BD *BD) State { return State{State: E.Data.Data} })
+	defer b.Dispose()
+
+	state := State{State: 5}
+	if err := b.Seed(nil, &state); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if value, hasState := b.GetState(); !hasState || value != state {
+		t.Errorf("Expected GetState To Be Of Value '%d' Actual '%d'", state.State, value.State)
+	}
+	if value := b.GetBloCData().BD; value != "a" {
+		t.Errorf("Expected GetBloCData To Be Of Value '%s' Actual '%s'", "a", value)
+	}
+
+	if err := b.StartListenToEventStream(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := b.Seed(&BD{BD: "b"}, nil); err == nil {
+		t.Errorf("Expected Seed To Return Error When Listened To")
+	}
+}
+
+func TestCreateBloCWithState(t *testing.T) {
+	var wg sync.WaitGroup
+	bd := BD{}
+	b := CreateBloCWithState(bd, State{State: 10}, func(CurrentState State, E event.Event[Event], BD *BD) State {
+		defer wg.Done()
+		return State{State: CurrentState.State + E.Data.Data}
+	})
+
+	if state, hasState := b.GetState(); !hasState || state.State != 10 {
+		t.Errorf("Expected GetState To Be Of Value '%d' Actual '%d'", 10, state.State)
+	}
+
+	err := b.StartListenToEventStream()
+	if err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+
+	wg.Add(2)
+	if err := b.AddEvent(Event{Data: 1}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	if err := b.AddEvent(Event{Data: 2}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	if state, _ := b.GetState(); state.State != 13 {
+		t.Errorf("Expected GetState To Be Of Value '%d' Actual '%d'", 13, state.State)
+	}
+	defer b.Dispose()
+}
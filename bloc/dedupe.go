@@ -0,0 +1,146 @@
+package bloc
+
+import (
+	"sync"
+	"time"
+
+	err "github.com/hijgo/go-bloc/error"
+	"github.com/hijgo/go-bloc/event"
+)
+
+// Remembers the keys of events that were already added to a BloC, see UseDeduplication.
+// Implementations must be safe for concurrent use.
+type DedupeStore interface {
+	// Returns true if the key was recorded before and is still within the window of the store, otherwise records the
+	// key and returns false. Checking and recording must happen atomically.
+	//
+	// Key : The key of the event
+	//
+	// At : The time the event was added
+	Seen(Key string, At time.Time) (bool, error)
+
+	// Removes the key, so an event with the same key will be accepted again. Used if the event was rejected by a
+	// later middleware.
+	//
+	// Key : The key of the event
+	Forget(Key string) error
+}
+
+// DedupeStore keeping keys in memory. Keys are evicted once more than MaxKeys keys are stored or once they are older
+// than MaxAge, whichever comes first.
+type MemoryDedupeStore struct {
+	lock    sync.Mutex
+	maxKeys int
+	maxAge  time.Duration
+	seen    map[string]time.Time
+	order   []string
+}
+
+// Function that should be called if a new MemoryDedupeStore is needed.
+//
+// MaxKeys : Maximum number of keys remembered, 0 for no limit
+//
+// MaxAge : Maximum time a key is remembered, 0 for no limit
+func CreateMemoryDedupeStore(MaxKeys int, MaxAge time.Duration) *MemoryDedupeStore {
+	return &MemoryDedupeStore{
+		maxKeys: MaxKeys,
+		maxAge:  MaxAge,
+		seen:    make(map[string]time.Time),
+		order:   make([]string, 0),
+	}
+}
+
+// Returns true if the key was recorded before and has not been evicted yet, otherwise records the key.
+//
+// Key : The key of the event
+//
+// At : The time the event was added, used to evict keys older than MaxAge
+func (s *MemoryDedupeStore) Seen(Key string, At time.Time) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.evict(At)
+	if _, seen := s.seen[Key]; seen {
+		return true, nil
+	}
+	s.seen[Key] = At
+	s.order = append(s.order, Key)
+	if s.maxKeys > 0 && len(s.order) > s.maxKeys {
+		delete(s.seen, s.order[0])
+		s.order = s.order[1:]
+	}
+	return false, nil
+}
+
+// Removes the key from the store.
+//
+// Key : The key of the event
+func (s *MemoryDedupeStore) Forget(Key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, seen := s.seen[Key]; !seen {
+		return nil
+	}
+	delete(s.seen, Key)
+	for i, key := range s.order {
+		if key == Key {
+			s.order = append(s.order[:i:i], s.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// Returns the number of keys currently remembered.
+func (s *MemoryDedupeStore) GetSize() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.order)
+}
+
+func (s *MemoryDedupeStore) evict(At time.Time) {
+	if s.maxAge <= 0 {
+		return
+	}
+	for len(s.order) > 0 && At.Sub(s.seen[s.order[0]]) >= s.maxAge {
+		delete(s.seen, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+// Appends a middleware to the chain of the BloC that drops events whose key was already seen, so retried events are
+// acknowledged without being added to the event stream again. AddEvent returns no error for dropped duplicates.
+// The time of the clock of the BloC is used as time an event was added. Middlewares added before will still see
+// duplicates, middlewares added after will not.
+//
+// Store : Remembers the keys of added events and defines the window in which duplicates are detected
+//
+// Key : Returns the key of an event, events with an empty key are never dropped. The id of the event is used if nil
+func (b *BloC[E, S, BD]) UseDeduplication(Store DedupeStore, Key func(NewEvent event.Event[E]) string) {
+	if Key == nil {
+		Key = func(NewEvent event.Event[E]) string { return NewEvent.ID }
+	}
+	b.UseEventMiddleware(func(NewEvent event.Event[E], Next EventHandler[E]) error {
+		key := Key(NewEvent)
+		if key == "" {
+			return Next(NewEvent)
+		}
+
+		seen, storeErr := Store.Seen(key, b.GetClock().Now())
+		if storeErr != nil {
+			return &err.Error{Context: "Cannot add event, deduplication failed!", Err: storeErr, Code: err.CodeDeduplication}
+		}
+		if seen {
+			return nil
+		}
+
+		if nextErr := Next(NewEvent); nextErr != nil {
+			if forgetErr := Store.Forget(key); forgetErr != nil {
+				return &err.Error{Context: "Cannot forget rejected event, deduplication failed!", Err: forgetErr, Code: err.CodeDeduplication}
+			}
+			return nextErr
+		}
+		return nil
+	})
+}
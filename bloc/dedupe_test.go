@@ -0,0 +1,142 @@
+package bloc
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	blocError "github.com/hijgo/go-bloc/error"
+	"github.com/hijgo/go-bloc/event"
+)
+
+func TestBloC_UseDeduplication(t *testing.T) {
+	b, c, states := createScheduledBloC(t)
+	defer b.Dispose()
+	store := CreateMemoryDedupeStore(0, time.Minute)
+	b.UseDeduplication(store, nil)
+
+	for i := 0; i < 3; i++ {
+		if err := b.AddEvent(Event{Data: 1}, event.WithID("request-1")); err != nil {
+			t.Errorf("Unexpected error occured: %s", err.Error())
+		}
+	}
+	if err := b.AddEvent(Event{Data: 2}, event.WithID("request-2")); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	c.Flush()
+
+	c.Advance(time.Minute)
+	if err := b.AddEvent(Event{Data: 3}, event.WithID("request-1")); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	c.Flush()
+
+	expected := []int{1, 2, 3}
+	if len(*states) != len(expected) {
+		t.Fatalf("Expected len(states) To Equal '%d' Actual '%d'", len(expected), len(*states))
+	}
+	for i, value := range *states {
+		if value != expected[i] {
+			t.Errorf("Expected State To Equal '%d' At Position '%d' Actual '%d'", expected[i], i, value)
+		}
+	}
+	if value := len(b.GetEventHistory()); value != 3 {
+		t.Errorf("Expected len(GetEventHistory) To Equal '%d' Actual '%d'", 3, value)
+	}
+}
+
+type failingDedupeStore struct{}
+
+func (failingDedupeStore) Seen(string, time.Time) (bool, error) {
+	return false, errors.New("store unavailable")
+}
+
+func (failingDedupeStore) Forget(string) error {
+	return errors.New("store unavailable")
+}
+
+func TestBloC_UseDeduplicationShouldReturnStoreErrors(t *testing.T) {
+	b, _, _ := createScheduledBloC(t)
+	defer b.Dispose()
+	b.UseDeduplication(failingDedupeStore{}, nil)
+
+	if err := b.AddEvent(Event{Data: 1}); !errors.Is(err, blocError.ErrDeduplication) {
+		t.Errorf("Expected AddEvent To Return Error '%s' Actual '%v'", blocError.ErrDeduplication, err)
+	}
+}
+
+func TestBloC_UseDeduplication_Key(t *testing.T) {
+	b, c, states := createScheduledBloC(t)
+	defer b.Dispose()
+	rejected := errors.New("rejected")
+	rejecting := true
+
+	b.UseDeduplication(CreateMemoryDedupeStore(10, 0), func(NewEvent event.Event[Event]) string {
+		if NewEvent.Data.Data < 0 {
+			return ""
+		}
+		return strconv.Itoa(NewEvent.Data.Data)
+	})
+	b.UseEventMiddleware(func(NewEvent event.Event[Event], Next EventHandler[Event]) error {
+		if rejecting && NewEvent.Data.Data == 5 {
+			return rejected
+		}
+		return Next(NewEvent)
+	})
+
+	for _, data := range []int{-1, -1, 4, 4} {
+		if err := b.AddEvent(Event{Data: data}); err != nil {
+			t.Errorf("Unexpected error occured: %s", err.Error())
+		}
+	}
+	if err := b.AddEvent(Event{Data: 5}); !errors.Is(err, rejected) {
+		t.Errorf("Expected AddEvent To Return Error '%v' Actual '%v'", rejected, err)
+	}
+	rejecting = false
+	if err := b.AddEvent(Event{Data: 5}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	c.Flush()
+
+	expected := []int{-1, -1, 4, 5}
+	if len(*states) != len(expected) {
+		t.Fatalf("Expected len(states) To Equal '%d' Actual '%d'", len(expected), len(*states))
+	}
+	for i, value := range *states {
+		if value != expected[i] {
+			t.Errorf("Expected State To Equal '%d' At Position '%d' Actual '%d'", expected[i], i, value)
+		}
+	}
+}
+
+func TestMemoryDedupeStore(t *testing.T) {
+	start := time.Unix(0, 0)
+	store := CreateMemoryDedupeStore(2, time.Second)
+
+	for _, key := range []string{"a", "b", "c"} {
+		if seen, _ := store.Seen(key, start); seen {
+			t.Errorf("Expected Key '%s' Not To Be Seen", key)
+		}
+	}
+	if value := store.GetSize(); value != 2 {
+		t.Errorf("Expected GetSize To Equal '%d' Actual '%d'", 2, value)
+	}
+	if seen, _ := store.Seen("c", start); !seen {
+		t.Errorf("Expected Key '%s' To Be Seen", "c")
+	}
+	if seen, _ := store.Seen("a", start); seen {
+		t.Errorf("Expected Evicted Key '%s' Not To Be Seen", "a")
+	}
+
+	_ = store.Forget("c")
+	if seen, _ := store.Seen("c", start); seen {
+		t.Errorf("Expected Forgotten Key '%s' Not To Be Seen", "c")
+	}
+	if seen, _ := store.Seen("a", start.Add(time.Second)); seen {
+		t.Errorf("Expected Expired Key '%s' Not To Be Seen", "a")
+	}
+	if value := store.GetSize(); value != 1 {
+		t.Errorf("Expected GetSize To Equal '%d' Actual '%d'", 1, value)
+	}
+}
@@ -0,0 +1,145 @@
+package bloc
+
+import (
+	"fmt"
+	"sync"
+
+	err "github.com/hijgo/go-bloc/error"
+	"github.com/hijgo/go-bloc/event"
+)
+
+// Keeps track of all dependencies between BloCs, to detect cycles when a new dependency is declared.
+// Maps the id of an upstream BloC to the ids of all BloCs depending on it and the number of such dependencies.
+var dependencies = struct {
+	lock       sync.Mutex
+	dependents map[uint64]map[uint64]int
+}{dependents: make(map[uint64]map[uint64]int)}
+
+// Registers a function that will be called with every new state of the BloC.
+// In contrast to ListenOnNewState any number of listeners can be registered and the listeners are called independent
+// of the state stream being listened to. The listeners will be called from the goroutine processing the event stream.
+//
+// OnNewState : Function that will be called with every new state
+//
+// Will return a function that removes the listener again.
+func (b *BloC[E, S, BD]) AddStateListener(OnNewState func(S)) func() {
+	b.core.lock.Lock()
+	defer b.core.lock.Unlock()
+	id := b.core.stateListeners.add(OnNewState)
+	return func() {
+		b.core.lock.Lock()
+		defer b.core.lock.Unlock()
+		b.core.stateListeners.remove(id)
+	}
+}
+
+// Registers a function that will be called once the BloC gets disposed, before its streams are closed.
+//
+// OnDispose : Function that will be called when the BloC gets disposed
+//
+// Will return a function that removes the listener again.
+func (b *BloC[E, S, BD]) AddDisposeListener(OnDispose func()) func() {
+	b.core.lock.Lock()
+	defer b.core.lock.Unlock()
+	id := b.core.disposeListeners.add(func(struct{}) { OnDispose() })
+	return func() {
+		b.core.lock.Lock()
+		defer b.core.lock.Unlock()
+		b.core.disposeListeners.remove(id)
+	}
+}
+
+// Declares that the Downstream BloC depends on the Upstream BloC. Every new state of the Upstream BloC will be
+// translated into an event that is added to the Downstream BloC, for example to clear a cart when a user logs out.
+// The translated event is a follow-up event of the upstream event that produced the state, see event.CausedBy.
+// The dependency will be removed automatically as soon as one of both BloCs gets disposed.
+//
+// Downstream : The BloC that should react to the states of the Upstream BloC
+//
+// Upstream : The BloC whose states should be reacted to
+//
+// Translate : Function translating a state of the Upstream BloC into an event of the Downstream BloC, returning false
+// if no event should be added for the given state
+//
+// Will return a function to remove the dependency again, or an error if the dependency would create a cycle or one of
+// both BloCs was already disposed.
+func DependOn[E any, S any, BD any, UE any, US any, UBD any](Downstream *BloC[E, S, BD], Upstream *BloC[UE, US, UBD], Translate func(UpstreamState US) (E, bool)) (func(), error) {
+	if Downstream.IsDisposed() || Upstream.IsDisposed() {
+		return nil, &err.Error{
+			Context: "Cannot declare dependency, BloC was disposed!",
+			Err:     fmt.Errorf("bloc was disposed"),
+			Code:    err.CodeDisposed,
+		}
+	}
+	if cycleErr := addDependency(Downstream.GetID(), Upstream.GetID()); cycleErr != nil {
+		return nil, cycleErr
+	}
+
+	var once sync.Once
+	var removeTransitionListener, removeUpstreamDispose, removeDownstreamDispose func()
+	remove := func() {
+		once.Do(func() {
+			removeTransitionListener()
+			removeUpstreamDispose()
+			removeDownstreamDispose()
+			removeDependency(Downstream.GetID(), Upstream.GetID())
+		})
+	}
+
+	removeTransitionListener = Upstream.AddTransitionListener(func(Transition Transition[UE, US, UBD]) {
+		if newEvent, ok := Translate(Transition.NextState); ok {
+			// Rejections are up to the event middleware of the downstream BloC to report
+			_ = Downstream.AddEvent(newEvent, event.CausedBy(Transition.Event.Metadata))
+		}
+	})
+	removeUpstreamDispose = Upstream.AddDisposeListener(remove)
+	removeDownstreamDispose = Downstream.AddDisposeListener(remove)
+	return remove, nil
+}
+
+// Adds the dependency of the downstream BloC on the upstream BloC, if it does not create a cycle.
+func addDependency(Downstream uint64, Upstream uint64) error {
+	dependencies.lock.Lock()
+	defer dependencies.lock.Unlock()
+
+	if Downstream == Upstream || dependsOn(Upstream, Downstream, make(map[uint64]bool)) {
+		return &err.Error{
+			Context: "Cannot declare dependency, BloCs would depend on each other!",
+			Err:     fmt.Errorf("dependency of bloc '%d' on bloc '%d' creates a cycle", Downstream, Upstream),
+			Code:    err.CodeCycle,
+		}
+	}
+
+	if dependencies.dependents[Upstream] == nil {
+		dependencies.dependents[Upstream] = make(map[uint64]int)
+	}
+	dependencies.dependents[Upstream][Downstream]++
+	return nil
+}
+
+// Returns true if Downstream depends directly or indirectly on Upstream. Must be called while holding the lock.
+func dependsOn(Downstream uint64, Upstream uint64, Visited map[uint64]bool) bool {
+	if Visited[Upstream] {
+		return false
+	}
+	Visited[Upstream] = true
+	for dependent := range dependencies.dependents[Upstream] {
+		if dependent == Downstream || dependsOn(Downstream, dependent, Visited) {
+			return true
+		}
+	}
+	return false
+}
+
+func removeDependency(Downstream uint64, Upstream uint64) {
+	dependencies.lock.Lock()
+	defer dependencies.lock.Unlock()
+
+	dependencies.dependents[Upstream][Downstream]--
+	if dependencies.dependents[Upstream][Downstream] <= 0 {
+		delete(dependencies.dependents[Upstream], Downstream)
+	}
+	if len(dependencies.dependents[Upstream]) == 0 {
+		delete(dependencies.dependents, Upstream)
+	}
+}
@@ -0,0 +1,188 @@
+package bloc
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/clock"
+	blocError "github.com/hijgo/go-bloc/error"
+	"github.com/hijgo/go-bloc/event"
+)
+
+type AuthState struct {
+	LoggedIn bool
+}
+
+func createAuthBloC() BloC[bool, AuthState, BD] {
+	return CreateBloC(BD{}, func(E event.Event[bool], BD *BD) AuthState { return AuthState{LoggedIn: E.Data} })
+}
+
+func TestBloC_AddStateListener(t *testing.T) {
+	var wg sync.WaitGroup
+	b := CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { return State{State: E.Data.Data} })
+	first, second := 0, 0
+	removeFirst := b.AddStateListener(func(S State) { first += S.State; wg.Done() })
+	b.AddStateListener(func(S State) { second += S.State; wg.Done() })
+
+	err := b.StartListenToEventStream()
+	if err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+
+	wg.Add(2)
+	if err := b.AddEvent(Event{Data: 1}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	removeFirst()
+	wg.Add(1)
+	if err := b.AddEvent(Event{Data: 2}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	if first != 1 {
+		t.Errorf("Expected first To Be Of Value '%d' Actual '%d'", 1, first)
+	}
+	if second != 3 {
+		t.Errorf("Expected second To Be Of Value '%d' Actual '%d'", 3, second)
+	}
+	defer b.Dispose()
+}
+
+func TestBloC_AddDisposeListener(t *testing.T) {
+	b := CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { return State{} })
+	called := 0
+	b.AddDisposeListener(func() { called++ })
+	removeListener := b.AddDisposeListener(func() { called += 10 })
+	removeListener()
+
+	b.Dispose()
+	b.Dispose()
+	if called != 1 {
+		t.Errorf("Expected called To Be Of Value '%d' Actual '%d'", 1, called)
+	}
+}
+
+func TestDependOn(t *testing.T) {
+	var wg sync.WaitGroup
+	auth := createAuthBloC()
+	cart := CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { defer wg.Done(); return State{State: E.Data.Data} })
+
+	_, err := DependOn(&cart, &auth, func(Auth AuthState) (Event, bool) {
+		return Event{Data: 0}, !Auth.LoggedIn
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	_ = cart.StartListenToEventStream()
+	_ = auth.StartListenToEventStream()
+
+	wg.Add(1)
+	if err := cart.AddEvent(Event{Data: 3}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	if err := auth.AddEvent(true); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Add(1)
+	if err := auth.AddEvent(false); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	if state, _ := cart.GetState(); state.State != 0 {
+		t.Errorf("Expected State To Be Of Value '%d' Actual '%d'", 0, state.State)
+	}
+	if value := len(cart.GetEventHistory()); value != 2 {
+		t.Errorf("Expected len(GetEventHistory) To Be Of Value '%d' Actual '%d'", 2, value)
+	}
+
+	cart.Dispose()
+	if value := len(auth.core.stateListeners.entries); value != 0 {
+		t.Errorf("Expected Upstream State Listeners To Be Removed Actual '%d'", value)
+	}
+	if _, exists := dependencies.dependents[auth.GetID()]; exists {
+		t.Errorf("Expected Dependency To Be Removed On Dispose")
+	}
+	auth.Dispose()
+}
+
+func TestDependOnShouldReturnErrorOnCycle(t *testing.T) {
+	a, b, c := createAuthBloC(), createAuthBloC(), createAuthBloC()
+	defer a.Dispose()
+	defer b.Dispose()
+	defer c.Dispose()
+	identity := func(S AuthState) (bool, bool) { return S.LoggedIn, true }
+
+	if _, err := DependOn(&b, &a, identity); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if _, err := DependOn(&c, &b, identity); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	if _, err := DependOn(&a, &c, identity); !errors.Is(err, blocError.ErrCycle) {
+		t.Errorf("Expected DependOn To Return Error '%s' On Cycle Actual '%v'", blocError.ErrCycle, err)
+	}
+	if _, err := DependOn(&a, &a, identity); err == nil {
+		t.Errorf("Expected DependOn To Return Error On Self Dependency")
+	}
+
+	remove, err := DependOn(&c, &a, identity)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	remove()
+	remove()
+}
+
+func TestDependOn_ShouldPropagateCorrelation(t *testing.T) {
+	v := clock.CreateVirtualClock(time.Unix(0, 0))
+	auth := createAuthBloC()
+	cart := CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { return State{State: E.Data.Data} })
+	defer auth.Dispose()
+	defer cart.Dispose()
+	for _, b := range []interface{ SetScheduler(clock.Scheduler) error }{&auth, &cart} {
+		if err := b.SetScheduler(v); err != nil {
+			t.Fatalf("Unexpected error occured: %s", err.Error())
+		}
+	}
+
+	if _, err := DependOn(&cart, &auth, func(US AuthState) (Event, bool) { return Event{Data: 0}, !US.LoggedIn }); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	transitions := make([]Transition[Event, State, BD], 0)
+	cart.AddTransitionListener(func(NewTransition Transition[Event, State, BD]) {
+		transitions = append(transitions, NewTransition)
+	})
+	if err := auth.StartListenToEventStream(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := cart.StartListenToEventStream(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	if err := auth.AddEvent(false, event.WithID("logout"), event.WithCorrelationID("session"), event.WithSource("auth")); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	v.Flush()
+
+	if value := len(transitions); value != 1 {
+		t.Fatalf("Expected len(transitions) To Be Of Value '%d' Actual '%d'", 1, value)
+	}
+	if value := transitions[0].Event.CausationID; value != "logout" {
+		t.Errorf("Expected CausationID To Equal '%s' Actual '%s'", "logout", value)
+	}
+	if value := transitions[0].Event.CorrelationID; value != "session" {
+		t.Errorf("Expected CorrelationID To Equal '%s' Actual '%s'", "session", value)
+	}
+	if value := auth.GetEventHistory()[0].Source; value != "auth" {
+		t.Errorf("Expected Source To Equal '%s' Actual '%s'", "auth", value)
+	}
+}
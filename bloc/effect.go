@@ -0,0 +1,101 @@
+package bloc
+
+import (
+	"sync"
+
+	"github.com/hijgo/go-bloc/event"
+)
+
+// A stream of one-shot side effects, like navigating to a page or showing a toast.
+// In contrast to states, effects are delivered exactly once to the listeners registered at the time the effect is
+// emitted and are never stored, so they will never be replayed to later listeners.
+//
+// F : Type of the effects
+type Effects[F any] struct {
+	lock      sync.RWMutex
+	listeners listeners[F]
+	disposed  bool
+}
+
+// Passes effects emitted while mapping an event to the Effects of a BloC, independent of the type of the effects.
+type effectDelivery interface {
+	deliver(Effect any)
+}
+
+// Function that should be called if new Effects are needed, that are not bound to a BloC.
+//
+// F : Type of the effects
+func CreateEffects[F any]() *Effects[F] {
+	return &Effects[F]{}
+}
+
+// Function that should be called if a new BloC is needed, that can emit side effects while mapping events.
+// The returned Effects will be disposed together with the BloC. Forks of the BloC will not emit effects.
+//
+// E : Type of events being emitted into the BloC
+//
+// S : Type of states being produced by the BloC from incoming events
+//
+// BD : BloCData Type of data that will be available to function that produces new states
+//
+// F : Type of the effects
+//
+// InitialBloCData : The initial BloCData struct being used by the bloc
+//
+// mapEventToState : Function that accepts an Event of Type event.Event[E], a BD ptr and a function to emit effects
+// to map the event to a new state of type S. Effects are delivered after the new state was emitted
+func CreateBloCWithEffects[E any, S any, BD any, F any](InitialBloCData BD, mapEventToState func(NewEvent event.Event[E], BloCData *BD, Emit func(Effect F)) S) (BloC[E, S, BD], *Effects[F]) {
+	effects := CreateEffects[F]()
+	newBloC := createBloC(InitialBloCData, nil, nil, func(_ S, NewEvent event.Event[E], BloCData *BD, Emit func(any), _ func(E, ...event.Option)) S {
+		return mapEventToState(NewEvent, BloCData, func(Effect F) { Emit(Effect) })
+	})
+	newBloC.core.effects = effects
+	newBloC.AddDisposeListener(effects.Dispose)
+	return newBloC, effects
+}
+
+// Registers a function that will be called with every effect emitted from now on.
+//
+// OnEffect : Function that will be called with every new effect
+//
+// Will return a function that removes the listener again.
+func (e *Effects[F]) Listen(OnEffect func(Effect F)) func() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	id := e.listeners.add(OnEffect)
+	return func() {
+		e.lock.Lock()
+		defer e.lock.Unlock()
+		e.listeners.remove(id)
+	}
+}
+
+// Delivers the effect to every listener currently registered. If no listener is registered or the Effects were
+// disposed, the effect is dropped.
+//
+// Effect : The effect that should be delivered
+func (e *Effects[F]) Emit(Effect F) {
+	e.lock.RLock()
+	if e.disposed {
+		e.lock.RUnlock()
+		return
+	}
+	listeners := e.listeners.snapshot()
+	e.lock.RUnlock()
+
+	for _, listener := range listeners {
+		listener(Effect)
+	}
+}
+
+func (e *Effects[F]) deliver(Effect any) {
+	e.Emit(Effect.(F))
+}
+
+// Removes all listeners, effects emitted afterwards will be dropped.
+func (e *Effects[F]) Dispose() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.disposed = true
+	e.listeners = listeners[F]{}
+}
@@ -0,0 +1,110 @@
+package bloc
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/hijgo/go-bloc/event"
+)
+
+type Toast struct {
+	Message string
+}
+
+func TestCreateBloCWithEffects(t *testing.T) {
+	var wg sync.WaitGroup
+	b, effects := CreateBloCWithEffects(BD{}, func(E event.Event[Event], BD *BD, Emit func(Toast)) State {
+		if E.Data.Data < 0 {
+			Emit(Toast{Message: "negative"})
+		}
+		return State{State: E.Data.Data}
+	})
+	b.AddTransitionListener(func(Transition[Event, State, BD]) { wg.Done() })
+
+	err := b.StartListenToEventStream()
+	if err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+
+	wg.Add(1)
+	if err := b.AddEvent(Event{Data: -1}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	received := make([]Toast, 0)
+	removeListener := effects.Listen(func(Effect Toast) { received = append(received, Effect); wg.Done() })
+
+	wg.Add(2)
+	if err := b.AddEvent(Event{Data: -2}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	if value := len(received); value != 1 || received[0].Message != "negative" {
+		t.Errorf("Expected received To Contain One Toast Actual '%v'", received)
+	}
+
+	removeListener()
+	wg.Add(1)
+	if err := b.AddEvent(Event{Data: -3}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+	if value := len(received); value != 1 {
+		t.Errorf("Expected len(received) To Be Of Value '%d' Actual '%d'", 1, value)
+	}
+
+	b.Dispose()
+	effects.Listen(func(Effect Toast) { received = append(received, Effect) })
+	effects.Emit(Toast{Message: "after dispose"})
+	if value := len(received); value != 1 {
+		t.Errorf("Expected Effects To Be Dropped After Dispose Actual '%d'", value)
+	}
+}
+
+func TestEffects_Emit(t *testing.T) {
+	effects := CreateEffects[string]()
+	first, second := make([]string, 0), make([]string, 0)
+
+	effects.Emit("dropped")
+	effects.Listen(func(Effect string) { first = append(first, Effect) })
+	effects.Emit("a")
+	effects.Listen(func(Effect string) { second = append(second, Effect) })
+	effects.Emit("b")
+
+	if value := len(first); value != 2 {
+		t.Errorf("Expected len(first) To Be Of Value '%d' Actual '%d'", 2, value)
+	}
+	if value := len(second); value != 1 || second[0] != "b" {
+		t.Errorf("Expected second To Only Contain '%s' Actual '%v'", "b", second)
+	}
+}
+
+func TestBloC_ForkShouldNotEmitEffects(t *testing.T) {
+	var wg sync.WaitGroup
+	b, effects := CreateBloCWithEffects(BD{}, func(E event.Event[Event], BD *BD, Emit func(Toast)) State {
+		Emit(Toast{Message: "mapped"})
+		return State{State: E.Data.Data}
+	})
+	defer b.Dispose()
+	received := 0
+	effects.Listen(func(Toast) { received++ })
+
+	forked := b.Fork(BD{}, nil)
+	defer forked.Dispose()
+	forked.AddTransitionListener(func(Transition[Event, State, BD]) { wg.Done() })
+	if err := forked.StartListenToEventStream(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	wg.Add(1)
+	if err := forked.AddEvent(Event{Data: 1}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	if received != 0 {
+		t.Errorf("Expected received To Be Of Value '%d' Actual '%d'", 0, received)
+	}
+}
@@ -0,0 +1,99 @@
+package bloc
+
+import (
+	"sync"
+
+	"github.com/hijgo/go-bloc/event"
+)
+
+type followUp[E any] struct {
+	data    E
+	options []event.Option
+}
+
+// Function that should be called if a new BloC is needed, whose mapEventToState can add follow-up events.
+// Follow-up events are added after the event causing them was mapped and its state was emitted. They share the
+// correlation id of the event causing them and use its id as causation id, see event.CausedBy.
+//
+// E : Type of events being emitted into the BloC
+//
+// S : Type of states being produced by the BloC from incoming events
+//
+// BD : BloCData Type of data that will be available to function that produces new states
+//
+// InitialBloCData : The initial BloCData struct being used by the bloc
+//
+// mapEventToState : Function that accepts an Event of Type event.Event[E], a BD ptr and a function to add follow-up
+// events to map the event to a new state of type S. Options passed to AddFollowUp are applied after event.CausedBy, so
+// they can override the correlation and causation id
+func CreateBloCWithFollowUps[E any, S any, BD any](InitialBloCData BD, mapEventToState func(NewEvent event.Event[E], BloCData *BD, AddFollowUp func(FollowUp E, Options ...event.Option)) S) BloC[E, S, BD] {
+	return createBloC(InitialBloCData, nil, nil, func(_ S, NewEvent event.Event[E], BloCData *BD, _ func(any), AddFollowUp func(E, ...event.Option)) S {
+		return mapEventToState(NewEvent, BloCData, AddFollowUp)
+	})
+}
+
+// Adds the follow-up events of the given cause. If the event stream waits for every event to be taken by its
+// listener, which is the goroutine calling this function, the follow-ups are passed to the follow-up queue of the BloC,
+// so follow-ups of all causes are added by a single goroutine in the order their causes were mapped.
+// Follow-ups rejected by a middleware or added after the BloC was disposed are dropped.
+func (b *BloC[E, S, BD]) addFollowUps(Cause event.Event[E], FollowUps []followUp[E]) {
+	if len(FollowUps) == 0 {
+		return
+	}
+	add := func() {
+		for _, next := range FollowUps {
+			options := append([]event.Option{event.CausedBy(Cause.Metadata)}, next.options...)
+			_ = b.AddEvent(next.data, options...)
+		}
+	}
+	if b.eventStream.AddBlocks() {
+		b.core.followUps.push(add)
+		return
+	}
+	add()
+}
+
+// Queue of functions adding follow-up events, run one after another by a single goroutine. The goroutine is started
+// when the first function is pushed and ends once the queue is empty or stopped.
+type followUpQueue struct {
+	lock    sync.Mutex
+	pending []func()
+	running bool
+	stopped bool
+}
+
+func (q *followUpQueue) push(Add func()) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.stopped {
+		return
+	}
+	q.pending = append(q.pending, Add)
+	if !q.running {
+		q.running = true
+		go q.drain()
+	}
+}
+
+func (q *followUpQueue) drain() {
+	for {
+		q.lock.Lock()
+		if q.stopped || len(q.pending) == 0 {
+			q.running = false
+			q.lock.Unlock()
+			return
+		}
+		next := q.pending[0]
+		q.pending = q.pending[1:]
+		q.lock.Unlock()
+		next()
+	}
+}
+
+// Drops all pending functions, functions pushed afterwards are never run.
+func (q *followUpQueue) stop() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.stopped = true
+	q.pending = nil
+}
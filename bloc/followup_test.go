@@ -0,0 +1,145 @@
+package bloc
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/clock"
+	"github.com/hijgo/go-bloc/event"
+)
+
+func createFollowUpBloC() BloC[Event, State, BD] {
+	return CreateBloCWithFollowUps(BD{}, func(E event.Event[Event], BD *BD, AddFollowUp func(FollowUp Event, Options ...event.Option)) State {
+		if E.Data.Data > 1 {
+			AddFollowUp(Event{Data: E.Data.Data - 1})
+		}
+		return State{State: E.Data.Data}
+	})
+}
+
+func TestCreateBloCWithFollowUps(t *testing.T) {
+	b := createFollowUpBloC()
+	defer b.Dispose()
+	c := clock.CreateVirtualClock(time.Unix(0, 0))
+	b.SetClock(c)
+	if err := b.SetScheduler(c); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := b.StartListenToEventStream(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	if err := b.AddEvent(Event{Data: 3}, event.WithID("root"), event.WithCorrelationID("session")); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	c.Flush()
+
+	history := b.GetEventHistory()
+	if value := len(history); value != 3 {
+		t.Fatalf("Expected len(GetEventHistory) To Equal '%d' Actual '%d'", 3, value)
+	}
+	for i, e := range history {
+		if value := e.Data.Data; value != 3-i {
+			t.Errorf("Expected Data Of Event '%d' To Equal '%d' Actual '%d'", i, 3-i, value)
+		}
+		if value := e.CorrelationID; value != "session" {
+			t.Errorf("Expected CorrelationID Of Event '%d' To Equal '%s' Actual '%s'", i, "session", value)
+		}
+		if i > 0 && e.CausationID != history[i-1].ID {
+			t.Errorf("Expected CausationID Of Event '%d' To Equal '%s' Actual '%s'", i, history[i-1].ID, e.CausationID)
+		}
+	}
+	if value := history[1].CausationID; value != "root" {
+		t.Errorf("Expected CausationID To Equal '%s' Actual '%s'", "root", value)
+	}
+	if state, _ := b.GetState(); state.State != 1 {
+		t.Errorf("Expected State To Be Of Value '%d' Actual '%d'", 1, state.State)
+	}
+}
+
+func TestCreateBloCWithFollowUps_Goroutine(t *testing.T) {
+	var wg sync.WaitGroup
+	b := createFollowUpBloC()
+	defer b.Dispose()
+	b.AddStateListener(func(State) { wg.Done() })
+	if err := b.StartListenToEventStream(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	wg.Add(3)
+	if err := b.AddEvent(Event{Data: 3}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	done := make(chan struct{})
+	go func() { wg.Wait(); close(done) }()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected Follow-Up Events To Be Mapped Without Deadlock")
+	}
+
+	history := b.GetEventHistory()
+	if value := history[2].CorrelationID; value != history[0].ID {
+		t.Errorf("Expected CorrelationID To Equal '%s' Actual '%s'", history[0].ID, value)
+	}
+}
+
+func TestCreateBloCWithFollowUps_GoroutineShouldKeepOrderOfCauses(t *testing.T) {
+	var wg sync.WaitGroup
+	b := CreateBloCWithFollowUps(BD{}, func(E event.Event[Event], BD *BD, AddFollowUp func(FollowUp Event, Options ...event.Option)) State {
+		if E.Data.Data < 10 {
+			AddFollowUp(Event{Data: E.Data.Data * 10})
+			AddFollowUp(Event{Data: E.Data.Data*10 + 1})
+		}
+		return State{State: E.Data.Data}
+	})
+	defer b.Dispose()
+	followUps := make([]int, 0)
+	b.AddStateListener(func(S State) {
+		if S.State >= 10 {
+			followUps = append(followUps, S.State)
+		}
+		wg.Done()
+	})
+	if err := b.StartListenToEventStream(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	wg.Add(15)
+	for i := 1; i <= 5; i++ {
+		if err := b.AddEvent(Event{Data: i}); err != nil {
+			t.Errorf("Unexpected error occured: %s", err.Error())
+		}
+	}
+	wg.Wait()
+
+	expected := []int{10, 11, 20, 21, 30, 31, 40, 41, 50, 51}
+	for i, value := range followUps {
+		if value != expected[i] {
+			t.Errorf("Expected Follow-Up To Equal '%d' At Position '%d' Actual '%d'", expected[i], i, value)
+		}
+	}
+}
+
+func TestFollowUpQueue_Stop(t *testing.T) {
+	var q followUpQueue
+	done := make(chan struct{})
+	ran := make([]int, 0)
+	q.push(func() { ran = append(ran, 1) })
+	q.push(func() { ran = append(ran, 2); close(done) })
+	<-done
+
+	q.stop()
+	q.push(func() { ran = append(ran, 3) })
+	time.Sleep(10 * time.Millisecond)
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if len(ran) != 2 || ran[0] != 1 || ran[1] != 2 {
+		t.Errorf("Expected ran To Equal '%v' Actual '%v'", []int{1, 2}, ran)
+	}
+	if q.running || len(q.pending) != 0 {
+		t.Errorf("Expected Stopped Queue To Hold No Pending Functions")
+	}
+}
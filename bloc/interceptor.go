@@ -0,0 +1,71 @@
+package bloc
+
+import "reflect"
+
+// A function that is called with every state produced by mapEventToState, before the state is emitted.
+// An interceptor can return a transformed state, or veto the state by returning false, in which case the BloC keeps
+// its current state and nothing is emitted.
+//
+// CurrentState : The current state of the BloC, only valid if HasCurrentState is true
+//
+// HasCurrentState : False if the BloC has no state yet
+//
+// NextState : The state produced by mapEventToState, or the state returned by the previous interceptor
+type StateInterceptor[S any] func(CurrentState S, HasCurrentState bool, NextState S) (S, bool)
+
+// Appends interceptors to the chain every new state passes before it is emitted.
+// Interceptors are called in the order they were added.
+//
+// Interceptors : The interceptors that should be added to the chain
+func (b *BloC[E, S, BD]) UseStateInterceptor(Interceptors ...StateInterceptor[S]) {
+	b.core.lock.Lock()
+	defer b.core.lock.Unlock()
+	b.core.stateInterceptors = append(b.core.stateInterceptors[:len(b.core.stateInterceptors):len(b.core.stateInterceptors)], Interceptors...)
+}
+
+// Suppresses every new state that equals the current state, so listeners are only informed about actual changes.
+// The check happens after all state interceptors were applied.
+//
+// Equal : Function deciding if two states are equal, nil to compare the states with ==. States whose type is not
+// comparable, like slices or maps, are never considered equal when no function is given
+func (b *BloC[E, S, BD]) DeduplicateStates(Equal func(a S, b S) bool) {
+	if Equal == nil {
+		Equal = comparableEqual[S]
+	}
+	b.core.lock.Lock()
+	defer b.core.lock.Unlock()
+	b.core.stateEqual = Equal
+}
+
+// Applies all interceptors and the deduplication to the next state. Must be called while holding the lock of the core.
+// Will return false as second value if the state should not be emitted.
+func (b *BloC[E, S, BD]) interceptState(CurrentState S, HasCurrentState bool, NextState S) (S, bool) {
+	for _, interceptor := range b.core.stateInterceptors {
+		var emit bool
+		if NextState, emit = interceptor(CurrentState, HasCurrentState, NextState); !emit {
+			return CurrentState, false
+		}
+	}
+	if HasCurrentState && b.core.stateEqual != nil && b.core.stateEqual(CurrentState, NextState) {
+		return CurrentState, false
+	}
+	return NextState, true
+}
+
+func comparableEqual[S any](a S, b S) (equal bool) {
+	// Comparable types can still hold values that are not comparable inside of interface fields
+	defer func() {
+		if recover() != nil {
+			equal = false
+		}
+	}()
+
+	aType, bType := reflect.TypeOf(any(a)), reflect.TypeOf(any(b))
+	if aType == nil || bType == nil {
+		return aType == bType
+	}
+	if aType != bType || !aType.Comparable() {
+		return false
+	}
+	return any(a) == any(b)
+}
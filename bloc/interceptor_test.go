@@ -0,0 +1,114 @@
+package bloc
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/hijgo/go-bloc/event"
+)
+
+func TestBloC_DeduplicateStates(t *testing.T) {
+	var wg sync.WaitGroup
+	b := CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { return State{State: E.Data.Data} })
+	b.DeduplicateStates(nil)
+	emitted := make([]int, 0)
+	b.AddStateListener(func(S State) { emitted = append(emitted, S.State); wg.Done() })
+
+	err := b.StartListenToEventStream()
+	if err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+
+	wg.Add(3)
+	for _, data := range []int{1, 1, 2, 1} {
+		if err := b.AddEvent(Event{Data: data}); err != nil {
+			t.Errorf("Unexpected error occured: %s", err.Error())
+		}
+	}
+	wg.Wait()
+
+	for i, expected := range []int{1, 2, 1} {
+		if value := emitted[i]; value != expected {
+			t.Errorf("Expected emitted To Be Of Value '%d' At Position '%d' Actual '%d'", expected, i, value)
+		}
+	}
+	if value := len(b.GetStateHistory()); value != 3 {
+		t.Errorf("Expected len(GetStateHistory) To Be Of Value '%d' Actual '%d'", 3, value)
+	}
+	defer b.Dispose()
+}
+
+func TestBloC_DeduplicateStatesWithEqual(t *testing.T) {
+	var wg sync.WaitGroup
+	b := CreateBloC(BD{}, func(E event.Event[Event], BD *BD) []int { return []int{E.Data.Data} })
+	b.DeduplicateStates(func(a []int, b []int) bool { return a[0]%2 == b[0]%2 })
+	b.AddStateListener(func([]int) { wg.Done() })
+
+	err := b.StartListenToEventStream()
+	if err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+
+	wg.Add(2)
+	for _, data := range []int{1, 3, 4} {
+		if err := b.AddEvent(Event{Data: data}); err != nil {
+			t.Errorf("Unexpected error occured: %s", err.Error())
+		}
+	}
+	wg.Wait()
+
+	if value := len(b.GetStateHistory()); value != 2 {
+		t.Errorf("Expected len(GetStateHistory) To Be Of Value '%d' Actual '%d'", 2, value)
+	}
+	defer b.Dispose()
+}
+
+func TestBloC_UseStateInterceptor(t *testing.T) {
+	var wg sync.WaitGroup
+	b := CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { return State{State: E.Data.Data} })
+	b.UseStateInterceptor(
+		func(CurrentState State, HasCurrentState bool, NextState State) (State, bool) {
+			return NextState, NextState.State >= 0
+		},
+		func(CurrentState State, HasCurrentState bool, NextState State) (State, bool) {
+			if HasCurrentState {
+				NextState.State += CurrentState.State
+			}
+			return NextState, true
+		},
+	)
+	b.AddStateListener(func(State) { wg.Done() })
+
+	err := b.StartListenToEventStream()
+	if err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+
+	wg.Add(2)
+	for _, data := range []int{1, -5, 2} {
+		if err := b.AddEvent(Event{Data: data}); err != nil {
+			t.Errorf("Unexpected error occured: %s", err.Error())
+		}
+	}
+	wg.Wait()
+
+	if state, _ := b.GetState(); state.State != 3 {
+		t.Errorf("Expected State To Be Of Value '%d' Actual '%d'", 3, state.State)
+	}
+	if value := len(b.GetStateHistory()); value != 2 {
+		t.Errorf("Expected len(GetStateHistory) To Be Of Value '%d' Actual '%d'", 2, value)
+	}
+	defer b.Dispose()
+}
+
+func TestComparableEqual(t *testing.T) {
+	if !comparableEqual(State{State: 1}, State{State: 1}) {
+		t.Errorf("Expected Equal States To Be Equal")
+	}
+	if comparableEqual([]int{1}, []int{1}) {
+		t.Errorf("Expected Slices To Never Be Equal")
+	}
+	if comparableEqual[any]([]int{1}, []int{1}) {
+		t.Errorf("Expected Slices Inside Interfaces To Never Be Equal")
+	}
+}
@@ -0,0 +1,225 @@
+package bloc
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	err "github.com/hijgo/go-bloc/error"
+)
+
+var lastBloCID uint64
+
+var lifecycleObservers struct {
+	lock    sync.RWMutex
+	entries []lifecycleObserverEntry
+	nextID  int
+}
+
+type lifecycleObserverEntry struct {
+	id       int
+	observer LifecycleObserver
+}
+
+// Will be informed whenever a BloC is created or disposed, can be used by tooling to keep track of all BloCs.
+type LifecycleObserver interface {
+	// Called after a new BloC was created by CreateBloC
+	OnCreate(BloC Inspectable)
+	// Called after a BloC was disposed
+	OnDispose(BloC Inspectable)
+}
+
+// Registers an observer that will be informed whenever a BloC is created or disposed.
+//
+// Observer : The observer that should be informed
+//
+// Will return a function that removes the observer again.
+func AddLifecycleObserver(Observer LifecycleObserver) func() {
+	lifecycleObservers.lock.Lock()
+	defer lifecycleObservers.lock.Unlock()
+	lifecycleObservers.nextID++
+	id := lifecycleObservers.nextID
+	lifecycleObservers.entries = append(lifecycleObservers.entries, lifecycleObserverEntry{id: id, observer: Observer})
+	return func() {
+		lifecycleObservers.lock.Lock()
+		defer lifecycleObservers.lock.Unlock()
+		for i, entry := range lifecycleObservers.entries {
+			if entry.id == id {
+				lifecycleObservers.entries = append(lifecycleObservers.entries[:i:i], lifecycleObservers.entries[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func notifyLifecycleObservers(BloC Inspectable, Notify func(LifecycleObserver, Inspectable)) {
+	lifecycleObservers.lock.RLock()
+	entries := lifecycleObservers.entries
+	lifecycleObservers.lock.RUnlock()
+
+	for _, entry := range entries {
+		Notify(entry.observer, BloC)
+	}
+}
+
+// A view on a BloC, that does not depend on the types of events, states and BloCData.
+// Is implemented by every BloC and meant to be used by tooling, like inspectors or registries.
+type Inspectable interface {
+	// Returns the unique id of the BloC
+	GetID() uint64
+	// Returns the name of the BloC
+	GetName() string
+	// Returns a snapshot of the current values of the BloC
+	Inspect() Snapshot
+	// Registers a function that will be called after every transition of the BloC and returns a function to remove it
+	AddTransitionObserver(OnTransition func(TransitionSnapshot)) func()
+	// Decodes the given JSON into an event of the BloC and adds it to the event stream
+	AddEventFromJSON(Data []byte) error
+	// Returns true if the BloC was disposed
+	IsDisposed() bool
+	// Disposes the BloC
+	Dispose()
+}
+
+// The current values of a BloC at the moment Inspect was called.
+//
+// ID : The unique id of the BloC
+//
+// Name : The name of the BloC
+//
+// Type : The type of the BloC including its type parameters
+//
+// State : The current state, only valid if HasState is true
+//
+// HasState : False if the BloC did not map any event yet
+//
+// BloCData : The current BloCData
+//
+// Events : The events currently stored in the history of the event stream
+//
+// Disposed : True if the BloC was disposed
+type Snapshot struct {
+	ID       uint64 `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	State    any    `json:"state"`
+	HasState bool   `json:"hasState"`
+	BloCData any    `json:"bloCData"`
+	Events   []any  `json:"events"`
+	Disposed bool   `json:"disposed"`
+}
+
+// A Transition of a BloC, that does not depend on the types of events, states and BloCData.
+//
+// BloCID : The unique id of the BloC the transition belongs to
+//
+// Event : The event that caused the transition
+//
+// PreviousState : The state before the event was mapped, only valid if HasPreviousState is true
+//
+// HasPreviousState : False if the transition was the first one of the BloC
+//
+// NextState : The state produced by mapping the event
+//
+// BloCData : The BloCData after the event was mapped
+//
+// StartedAt : Time the mapping of the event started
+//
+// FinishedAt : Time the mapping of the event finished
+type TransitionSnapshot struct {
+	BloCID           uint64    `json:"bloCId"`
+	Event            any       `json:"event"`
+	PreviousState    any       `json:"previousState"`
+	HasPreviousState bool      `json:"hasPreviousState"`
+	NextState        any       `json:"nextState"`
+	BloCData         any       `json:"bloCData"`
+	StartedAt        time.Time `json:"startedAt"`
+	FinishedAt       time.Time `json:"finishedAt"`
+}
+
+// Returns the unique id of the BloC, assigned when the BloC was created.
+func (b *BloC[E, S, BD]) GetID() uint64 {
+	return b.core.id
+}
+
+// Returns the name of the BloC. If no name was set, the type of the BloC will be returned.
+func (b *BloC[E, S, BD]) GetName() string {
+	b.core.lock.RLock()
+	defer b.core.lock.RUnlock()
+	if b.core.name == "" {
+		return b.typeName()
+	}
+	return b.core.name
+}
+
+// Sets a human-readable name of the BloC, for example to identify it inside of an inspector.
+//
+// Name : The new name of the BloC
+func (b *BloC[E, S, BD]) SetName(Name string) {
+	b.core.lock.Lock()
+	defer b.core.lock.Unlock()
+	b.core.name = Name
+}
+
+func (b *BloC[E, S, BD]) typeName() string {
+	return reflect.TypeOf(b).Elem().String()
+}
+
+// Returns a snapshot of the current values of the BloC.
+func (b *BloC[E, S, BD]) Inspect() Snapshot {
+	state, hasState := b.GetState()
+	history := b.GetEventHistory()
+	events := make([]any, 0, len(history))
+	for _, e := range history {
+		events = append(events, e)
+	}
+	return Snapshot{
+		ID:       b.GetID(),
+		Name:     b.GetName(),
+		Type:     b.typeName(),
+		State:    state,
+		HasState: hasState,
+		BloCData: b.GetBloCData(),
+		Events:   events,
+		Disposed: b.IsDisposed(),
+	}
+}
+
+// Registers a function that will be called with a TransitionSnapshot after every transition of the BloC.
+//
+// OnTransition : Function that will be called with every new transition
+//
+// Will return a function that removes the observer again.
+func (b *BloC[E, S, BD]) AddTransitionObserver(OnTransition func(TransitionSnapshot)) func() {
+	return b.AddTransitionListener(func(Transition Transition[E, S, BD]) {
+		OnTransition(TransitionSnapshot{
+			BloCID:           b.GetID(),
+			Event:            Transition.Event,
+			PreviousState:    Transition.PreviousState,
+			HasPreviousState: Transition.HasPreviousState,
+			NextState:        Transition.NextState,
+			BloCData:         Transition.BloCData,
+			StartedAt:        Transition.StartedAt,
+			FinishedAt:       Transition.FinishedAt,
+		})
+	})
+}
+
+// Decodes the given JSON into an event of type E and adds it to the event stream.
+//
+// Data : The JSON encoded event
+//
+// Will return an error if the JSON could not be decoded into an event of type E or the event was rejected.
+func (b *BloC[E, S, BD]) AddEventFromJSON(Data []byte) error {
+	var newEvent E
+	if decodeErr := json.Unmarshal(Data, &newEvent); decodeErr != nil {
+		return &err.Error{
+			Context: "Cannot add event, JSON could not be decoded!",
+			Err:     fmt.Errorf("cannot decode event of type '%s': %w", reflect.TypeOf(&newEvent).Elem(), decodeErr),
+			Code:    err.CodeDecode,
+		}
+	}
+	return b.AddEvent(newEvent)
+}
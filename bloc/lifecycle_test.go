@@ -0,0 +1,106 @@
+package bloc
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/hijgo/go-bloc/event"
+)
+
+type lifecycleRecorder struct {
+	created  []uint64
+	disposed []uint64
+}
+
+func (l *lifecycleRecorder) OnCreate(BloC Inspectable) { l.created = append(l.created, BloC.GetID()) }
+func (l *lifecycleRecorder) OnDispose(BloC Inspectable) {
+	l.disposed = append(l.disposed, BloC.GetID())
+}
+
+func TestAddLifecycleObserver(t *testing.T) {
+	recorder := &lifecycleRecorder{}
+	removeObserver := AddLifecycleObserver(recorder)
+
+	b := CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { return State{} })
+	if value := len(recorder.created); value != 1 || recorder.created[0] != b.GetID() {
+		t.Errorf("Expected created To Contain '%d' Actual '%v'", b.GetID(), recorder.created)
+	}
+
+	b.Dispose()
+	b.Dispose()
+	if value := len(recorder.disposed); value != 1 || recorder.disposed[0] != b.GetID() {
+		t.Errorf("Expected disposed To Contain '%d' Once Actual '%v'", b.GetID(), recorder.disposed)
+	}
+	if value := b.IsDisposed(); !value {
+		t.Errorf("Expected IsDisposed To Be Of Value '%t' Actual '%t'", true, value)
+	}
+
+	removeObserver()
+	CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { return State{} })
+	if value := len(recorder.created); value != 1 {
+		t.Errorf("Expected len(created) To Be Of Value '%d' Actual '%d'", 1, value)
+	}
+}
+
+func TestBloC_GetID(t *testing.T) {
+	b1 := CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { return State{} })
+	b2 := CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { return State{} })
+
+	if b1.GetID() == b2.GetID() {
+		t.Errorf("Expected GetID To Be Unique Actual '%d' '%d'", b1.GetID(), b2.GetID())
+	}
+}
+
+func TestBloC_GetName(t *testing.T) {
+	b := CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { return State{} })
+
+	if value := b.GetName(); value != "bloc.BloC[github.com/hijgo/go-bloc/bloc.Event,github.com/hijgo/go-bloc/bloc.State,github.com/hijgo/go-bloc/bloc.BD]" {
+		t.Errorf("Expected GetName To Default To Type Actual '%s'", value)
+	}
+
+	b.SetName("counter")
+	if value := b.GetName(); value != "counter" {
+		t.Errorf("Expected GetName To Be Of Value '%s' Actual '%s'", "counter", value)
+	}
+}
+
+func TestBloC_InspectAndAddEventFromJSON(t *testing.T) {
+	var wg sync.WaitGroup
+	b := CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { return State{State: E.Data.Data} })
+	var snapshots []TransitionSnapshot
+	b.AddTransitionObserver(func(Transition TransitionSnapshot) {
+		snapshots = append(snapshots, Transition)
+		wg.Done()
+	})
+
+	err := b.StartListenToEventStream()
+	if err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+
+	if err := b.AddEventFromJSON([]byte(`{"Data":`)); err == nil {
+		t.Errorf("Expected AddEventFromJSON To Return Error When JSON Invalid")
+	}
+
+	wg.Add(1)
+	if err := b.AddEventFromJSON([]byte(`{"Data":4}`)); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	if value := snapshots[0].NextState.(State).State; value != 4 {
+		t.Errorf("Expected NextState To Be Of Value '%d' Actual '%d'", 4, value)
+	}
+	if value := snapshots[0].BloCID; value != b.GetID() {
+		t.Errorf("Expected BloCID To Be Of Value '%d' Actual '%d'", b.GetID(), value)
+	}
+
+	snapshot := b.Inspect()
+	if value := snapshot.State.(State).State; !snapshot.HasState || value != 4 {
+		t.Errorf("Expected State To Be Of Value '%d' Actual '%d'", 4, value)
+	}
+	if value := len(snapshot.Events); value != 1 {
+		t.Errorf("Expected len(Events) To Be Of Value '%d' Actual '%d'", 1, value)
+	}
+	defer b.Dispose()
+}
@@ -0,0 +1,71 @@
+package bloc
+
+import (
+	"github.com/hijgo/go-bloc/event"
+)
+
+// Function handling an event, returns an error if the event was rejected.
+//
+// NewEvent : The event that should be handled
+type EventHandler[E any] func(NewEvent event.Event[E]) error
+
+// A single step of the chain every event passes before it is added to the event stream.
+// A middleware can inspect the event, pass a modified copy of it to Next, or reject it by returning an error without
+// calling Next. Events that are not passed to Next will never reach the event stream.
+//
+// NewEvent : The event that was added to the BloC, or the event passed on by the previous middleware
+//
+// Next : The next step of the chain, must be called to pass the event on
+type EventMiddleware[E any] func(NewEvent event.Event[E], Next EventHandler[E]) error
+
+// Appends middlewares to the chain every new event passes before it is added to the event stream.
+// Middlewares are called in the order they were added, the first added middleware sees each event first.
+//
+// Middlewares : The middlewares that should be added to the chain
+func (b *BloC[E, S, BD]) UseEventMiddleware(Middlewares ...EventMiddleware[E]) {
+	b.core.lock.Lock()
+	defer b.core.lock.Unlock()
+	b.core.eventMiddlewares = append(b.core.eventMiddlewares[:len(b.core.eventMiddlewares):len(b.core.eventMiddlewares)], Middlewares...)
+}
+
+// Registers a function that will be called with every event added to the BloC, before it is passed to the first
+// middleware. Unlike transition listeners, it is also called for events that are rejected or dropped by a middleware
+// and for events that do not change the state.
+//
+// OnDispatch : Function that will be called with every added event
+//
+// Will return a function that removes the listener again.
+func (b *BloC[E, S, BD]) AddDispatchListener(OnDispatch func(NewEvent event.Event[E])) func() {
+	b.core.lock.Lock()
+	defer b.core.lock.Unlock()
+	id := b.core.dispatchListeners.add(OnDispatch)
+	return func() {
+		b.core.lock.Lock()
+		defer b.core.lock.Unlock()
+		b.core.dispatchListeners.remove(id)
+	}
+}
+
+// Informs all dispatch listeners, then passes the event through all middlewares and finally adds it to the event stream.
+func (b *BloC[E, S, BD]) dispatch(NewEvent event.Event[E]) error {
+	b.core.lock.RLock()
+	middlewares := b.core.eventMiddlewares
+	dispatchListeners := b.core.dispatchListeners.snapshot()
+	b.core.lock.RUnlock()
+
+	for _, listener := range dispatchListeners {
+		listener(NewEvent)
+	}
+
+	var handler EventHandler[E] = func(NewEvent event.Event[E]) error {
+		b.eventStream.Add(NewEvent)
+		return nil
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		middleware, next := middlewares[i], handler
+		handler = func(NewEvent event.Event[E]) error {
+			return middleware(NewEvent, next)
+		}
+	}
+	return handler(NewEvent)
+}
@@ -0,0 +1,89 @@
+package bloc
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/hijgo/go-bloc/event"
+)
+
+func TestBloC_UseEventMiddleware(t *testing.T) {
+	var wg sync.WaitGroup
+	b := CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { defer wg.Done(); return State{State: E.Data.Data} })
+	calls := make([]string, 0)
+	rejected := errors.New("negative events are not allowed")
+
+	b.UseEventMiddleware(
+		func(NewEvent event.Event[Event], Next EventHandler[Event]) error {
+			calls = append(calls, "validate")
+			if NewEvent.Data.Data < 0 {
+				return rejected
+			}
+			return Next(NewEvent)
+		},
+		func(NewEvent event.Event[Event], Next EventHandler[Event]) error {
+			calls = append(calls, "enrich")
+			NewEvent.Data.Data *= 10
+			return Next(NewEvent)
+		},
+	)
+
+	err := b.StartListenToEventStream()
+	if err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+
+	if err := b.AddEvent(Event{Data: -1}); !errors.Is(err, rejected) {
+		t.Errorf("Expected AddEvent To Return Error '%v' Actual '%v'", rejected, err)
+	}
+
+	wg.Add(1)
+	if err := b.AddEvent(Event{Data: 2}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	if state, _ := b.GetState(); state.State != 20 {
+		t.Errorf("Expected State To Be Of Value '%d' Actual '%d'", 20, state.State)
+	}
+	if value := len(b.GetEventHistory()); value != 1 {
+		t.Errorf("Expected len(GetEventHistory) To Be Of Value '%d' Actual '%d'", 1, value)
+	}
+	expectedCalls := []string{"validate", "validate", "enrich"}
+	for i, expected := range expectedCalls {
+		if value := calls[i]; value != expected {
+			t.Errorf("Expected calls To Be Of Value '%s' At Position '%d' Actual '%s'", expected, i, value)
+		}
+	}
+	defer b.Dispose()
+}
+
+func TestBloC_AddEventShouldReturnErrorWhenDisposed(t *testing.T) {
+	b := CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { return State{} })
+	b.Dispose()
+
+	if err := b.AddEvent(Event{Data: 1}); err == nil {
+		t.Errorf("Expected AddEvent To Return Error When Disposed")
+	}
+}
+
+func TestBloC_AddDispatchListener(t *testing.T) {
+	b := CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { return State{} })
+	defer b.Dispose()
+	dispatched := make([]int, 0)
+	removeListener := b.AddDispatchListener(func(NewEvent event.Event[Event]) { dispatched = append(dispatched, NewEvent.Data.Data) })
+	b.UseEventMiddleware(func(NewEvent event.Event[Event], Next EventHandler[Event]) error {
+		return errors.New("rejected")
+	})
+
+	if err := b.AddEvent(Event{Data: 1}); err == nil {
+		t.Errorf("Expected AddEvent To Return Error When Rejected")
+	}
+	removeListener()
+	_ = b.AddEvent(Event{Data: 2})
+
+	if value := len(dispatched); value != 1 || dispatched[0] != 1 {
+		t.Errorf("Expected dispatched To Equal '%v' Actual '%v'", []int{1}, dispatched)
+	}
+}
@@ -0,0 +1,40 @@
+package bloc
+
+import (
+	"github.com/hijgo/go-bloc/event"
+)
+
+// Makes the BloC process pending events by priority instead of strictly in the order they were added, see
+// event.WithPriority. Events of the same priority are still processed in the order they were added.
+// Expired events, see event.WithDeadline and event.WithTTL, are dropped whether priority lanes are used or not.
+//
+// Will return an error if the event stream is currently listened to.
+func (b *BloC[E, S, BD]) UsePriorityLanes() error {
+	return b.eventStream.UsePriorityLanes()
+}
+
+// Registers a function that will be called for every event that expired before it could be mapped to a new state.
+// The function will be called from the goroutine processing the event stream.
+//
+// OnExpiredEvent : Function that will be called with every expired event
+//
+// Will return a function that removes the listener again.
+func (b *BloC[E, S, BD]) AddExpiredEventListener(OnExpiredEvent func(ExpiredEvent event.Event[E])) func() {
+	b.core.lock.Lock()
+	defer b.core.lock.Unlock()
+	id := b.core.expiredListeners.add(OnExpiredEvent)
+	return func() {
+		b.core.lock.Lock()
+		defer b.core.lock.Unlock()
+		b.core.expiredListeners.remove(id)
+	}
+}
+
+func (b *BloC[E, S, BD]) handleExpiredEvent(ExpiredEvent event.Event[E]) {
+	b.core.lock.RLock()
+	notifies := b.core.expiredListeners.snapshot()
+	b.core.lock.RUnlock()
+	for _, notify := range notifies {
+		notify(ExpiredEvent)
+	}
+}
@@ -0,0 +1,59 @@
+package bloc
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/clock"
+	"github.com/hijgo/go-bloc/event"
+)
+
+func TestBloC_UsePriorityLanes(t *testing.T) {
+	states := make([]int, 0)
+	expired := make([]int, 0)
+	b := CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { return State{State: E.Data.Data} })
+	defer b.Dispose()
+	c := clock.CreateVirtualClock(time.Unix(0, 0))
+	b.SetClock(c)
+	if err := b.SetScheduler(c); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := b.UsePriorityLanes(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	b.AddStateListener(func(NewState State) { states = append(states, NewState.State) })
+	remove := b.AddExpiredEventListener(func(ExpiredEvent event.Event[Event]) {
+		expired = append(expired, ExpiredEvent.Data.Data)
+	})
+	if err := b.StartListenToEventStream(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := b.UsePriorityLanes(); err == nil {
+		t.Errorf("Expected Error When Using Priority Lanes On Listened BloC")
+	}
+
+	_ = b.AddEvent(Event{Data: 1})
+	_ = b.AddEvent(Event{Data: 2}, event.WithDeadline(c.Now()))
+	_ = b.AddEvent(Event{Data: 3}, event.WithPriority(1))
+	_ = b.AddEvent(Event{Data: 4})
+	c.Flush()
+
+	if expected := []int{3, 1, 4}; !reflect.DeepEqual(states, expected) {
+		t.Errorf("Expected states To Equal '%v' Actual '%v'", expected, states)
+	}
+	if expected := []int{2}; !reflect.DeepEqual(expired, expected) {
+		t.Errorf("Expected expired To Equal '%v' Actual '%v'", expected, expired)
+	}
+
+	remove()
+	_ = b.AddEvent(Event{Data: 5}, event.WithTTL(-time.Second))
+	_ = b.AddEvent(Event{Data: 6}, event.WithDeadline(c.Now()))
+	c.Flush()
+	if value := len(expired); value != 1 {
+		t.Errorf("Expected len(expired) To Equal '%d' Actual '%d'", 1, value)
+	}
+	if expected := []int{3, 1, 4, 5}; !reflect.DeepEqual(states, expected) {
+		t.Errorf("Expected states To Equal '%v' Actual '%v'", expected, states)
+	}
+}
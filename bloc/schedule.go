@@ -0,0 +1,176 @@
+package bloc
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hijgo/go-bloc/clock"
+	err "github.com/hijgo/go-bloc/error"
+)
+
+// Handle of an event that will be added to a BloC in the future, see AddEventAfter, AddEventAt and AddEventEvery.
+// Scheduled events are cancelled automatically when the BloC gets disposed.
+type ScheduledEvent struct {
+	lock                  sync.Mutex
+	timer                 clock.Timer
+	cancelled             bool
+	removeDisposeListener func()
+}
+
+// Prevents the event from being added to the BloC again.
+//
+// Will return false if the event was already cancelled or, for events that are not periodic, was already added.
+func (s *ScheduledEvent) Cancel() bool {
+	s.lock.Lock()
+	if s.cancelled {
+		s.lock.Unlock()
+		return false
+	}
+	s.cancelled = true
+	stopped := s.timer.Stop()
+	removeDisposeListener := s.removeDisposeListener
+	s.lock.Unlock()
+
+	removeDisposeListener()
+	return stopped
+}
+
+// Returns true if the event was cancelled, either by calling Cancel or by disposing the BloC.
+func (s *ScheduledEvent) IsCancelled() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.cancelled
+}
+
+// Replaces the clock used by the BloC to timestamp events, transitions and histories and to schedule events, for
+// example a clock.Virtual to control time in tests. Only affects events scheduled afterwards.
+//
+// Clock : The clock that should be used, clock.Real by default
+func (b *BloC[E, S, BD]) SetClock(Clock clock.Clock) {
+	b.core.lock.Lock()
+	b.core.clock = Clock
+	b.core.lock.Unlock()
+	b.eventStream.SetClock(Clock)
+	b.stateStream.SetClock(Clock)
+}
+
+// Replaces the goroutines delivering events and states with the given scheduler, for example a clock.Virtual to map
+// events deterministically by calling Flush. Has to be called before listening to the event or state stream.
+//
+// Scheduler : The scheduler that should deliver events and states, nil to use goroutines again
+//
+// Will return an error if the event or state stream is currently listened to.
+func (b *BloC[E, S, BD]) SetScheduler(Scheduler clock.Scheduler) error {
+	if b.eventStream.GetListenStatus() || b.stateStream.GetListenStatus() {
+		return &err.Error{
+			Context: "Cannot change scheduler, BloC is listened to!",
+			Err:     fmt.Errorf("stream already listened to"),
+			Code:    err.CodeAlreadyListening,
+		}
+	}
+	_ = b.eventStream.SetScheduler(Scheduler)
+	_ = b.stateStream.SetScheduler(Scheduler)
+	return nil
+}
+
+// Returns the clock used by the BloC to schedule events.
+func (b *BloC[E, S, BD]) GetClock() clock.Clock {
+	b.core.lock.RLock()
+	defer b.core.lock.RUnlock()
+	if b.core.clock == nil {
+		return clock.Real
+	}
+	return b.core.clock
+}
+
+// Adds the event to the BloC once the delay has elapsed, as if AddEvent was called at that point.
+// Errors returned by AddEvent at that point, for example because a middleware rejected the event, are dropped.
+//
+// NewEvent : The event of type E that should be added
+//
+// Delay : Time to wait before the event is added
+//
+// Will return an error if the BloC was disposed.
+func (b *BloC[E, S, BD]) AddEventAfter(NewEvent E, Delay time.Duration) (*ScheduledEvent, error) {
+	return b.schedule(NewEvent, Delay, 0)
+}
+
+// Adds the event to the BloC at the given time, as if AddEvent was called at that point. If the time already passed,
+// the event will be added immediately.
+// Errors returned by AddEvent at that point, for example because a middleware rejected the event, are dropped.
+//
+// NewEvent : The event of type E that should be added
+//
+// At : The time the event should be added at
+//
+// Will return an error if the BloC was disposed.
+func (b *BloC[E, S, BD]) AddEventAt(NewEvent E, At time.Time) (*ScheduledEvent, error) {
+	return b.schedule(NewEvent, At.Sub(b.GetClock().Now()), 0)
+}
+
+// Adds the event to the BloC every time the interval has elapsed, until the ScheduledEvent is cancelled or the BloC
+// gets disposed. The first event will be added after one interval.
+// Errors returned by AddEvent, for example because a middleware rejected the event, are dropped.
+//
+// NewEvent : The event of type E that should be added
+//
+// Interval : Time between two events
+//
+// Will return an error if the BloC was disposed or the interval is not positive.
+func (b *BloC[E, S, BD]) AddEventEvery(NewEvent E, Interval time.Duration) (*ScheduledEvent, error) {
+	if Interval <= 0 {
+		return nil, &err.Error{
+			Context: "Cannot schedule event, interval must be positive!",
+			Err:     fmt.Errorf("invalid interval '%s'", Interval),
+			Code:    err.CodeInvalidArgument,
+		}
+	}
+	return b.schedule(NewEvent, Interval, Interval)
+}
+
+func (b *BloC[E, S, BD]) schedule(NewEvent E, Delay time.Duration, Interval time.Duration) (*ScheduledEvent, error) {
+	if b.IsDisposed() {
+		return nil, &err.Error{
+			Context: "Cannot schedule event, BloC was disposed!",
+			Err:     fmt.Errorf("bloc was disposed"),
+			Code:    err.CodeDisposed,
+		}
+	}
+	if Delay < 0 {
+		Delay = 0
+	}
+
+	c := b.GetClock()
+	scheduled := &ScheduledEvent{}
+	next := c.Now().Add(Delay)
+
+	var fire func()
+	fire = func() {
+		scheduled.lock.Lock()
+		if scheduled.cancelled {
+			scheduled.lock.Unlock()
+			return
+		}
+		if Interval > 0 {
+			// Planned relative to the previous planned time, so the events do not drift.
+			next = next.Add(Interval)
+			scheduled.timer = c.AfterFunc(next.Sub(c.Now()), fire)
+		}
+		scheduled.lock.Unlock()
+
+		_ = b.AddEvent(NewEvent)
+		if Interval == 0 {
+			scheduled.lock.Lock()
+			removeDisposeListener := scheduled.removeDisposeListener
+			scheduled.lock.Unlock()
+			removeDisposeListener()
+		}
+	}
+
+	scheduled.lock.Lock()
+	defer scheduled.lock.Unlock()
+	scheduled.removeDisposeListener = b.AddDisposeListener(func() { scheduled.Cancel() })
+	scheduled.timer = c.AfterFunc(Delay, fire)
+	return scheduled, nil
+}
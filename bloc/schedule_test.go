@@ -0,0 +1,145 @@
+package bloc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/clock"
+	blocError "github.com/hijgo/go-bloc/error"
+	"github.com/hijgo/go-bloc/event"
+)
+
+func createScheduledBloC(t *testing.T) (BloC[Event, State, BD], *clock.Virtual, *[]int) {
+	states := make([]int, 0)
+	b := CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { return State{State: E.Data.Data} })
+	c := clock.CreateVirtualClock(time.Unix(0, 0))
+	b.SetClock(c)
+	if err := b.SetScheduler(c); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	b.AddStateListener(func(NewState State) { states = append(states, NewState.State) })
+	if err := b.StartListenToEventStream(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	return b, c, &states
+}
+
+func TestBloC_AddEventAfter(t *testing.T) {
+	b, c, states := createScheduledBloC(t)
+	defer b.Dispose()
+
+	scheduled, err := b.AddEventAfter(Event{Data: 1}, 5*time.Second)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	c.Advance(4 * time.Second)
+	if value := len(*states); value != 0 {
+		t.Errorf("Expected len(states) To Be Of Value '%d' Actual '%d'", 0, value)
+	}
+
+	c.Advance(time.Second)
+	if value := (*states)[0]; value != 1 {
+		t.Errorf("Expected states[0] To Be Of Value '%d' Actual '%d'", 1, value)
+	}
+	if scheduled.Cancel() {
+		t.Errorf("Expected Cancel Of Added Event To Return '%t'", false)
+	}
+}
+
+func TestBloC_AddEventAt(t *testing.T) {
+	b, c, states := createScheduledBloC(t)
+	defer b.Dispose()
+
+	if _, err := b.AddEventAt(Event{Data: 2}, time.Unix(60, 0)); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	c.Advance(time.Minute)
+	if value := (*states)[0]; value != 2 {
+		t.Errorf("Expected states[0] To Be Of Value '%d' Actual '%d'", 2, value)
+	}
+}
+
+func TestBloC_AddEventEvery(t *testing.T) {
+	b, c, states := createScheduledBloC(t)
+	defer b.Dispose()
+
+	if _, err := b.AddEventEvery(Event{Data: 3}, 0); !errors.Is(err, blocError.ErrInvalidArgument) {
+		t.Errorf("Expected Error '%s' For Interval '%d' Actual '%v'", blocError.ErrInvalidArgument, 0, err)
+	}
+
+	scheduled, err := b.AddEventEvery(Event{Data: 3}, time.Minute)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	c.Advance(3 * time.Minute)
+
+	if !scheduled.Cancel() {
+		t.Errorf("Expected Cancel To Return '%t'", true)
+	}
+	if !scheduled.IsCancelled() {
+		t.Errorf("Expected IsCancelled To Return '%t'", true)
+	}
+	c.Advance(3 * time.Minute)
+	if value := len(*states); value != 3 {
+		t.Errorf("Expected len(states) To Be Of Value '%d' Actual '%d'", 3, value)
+	}
+}
+
+func TestBloC_DisposeShouldCancelScheduledEvents(t *testing.T) {
+	b, c, states := createScheduledBloC(t)
+
+	scheduled, err := b.AddEventEvery(Event{Data: 4}, time.Second)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	b.Dispose()
+	if !scheduled.IsCancelled() {
+		t.Errorf("Expected IsCancelled To Return '%t'", true)
+	}
+	c.Advance(time.Minute)
+	if value := len(*states); value != 0 {
+		t.Errorf("Expected len(states) To Be Of Value '%d' Actual '%d'", 0, value)
+	}
+
+	if _, err := b.AddEventAfter(Event{Data: 4}, time.Second); !errors.Is(err, blocError.ErrDisposed) {
+		t.Errorf("Expected Error '%s' When Scheduling On Disposed BloC Actual '%v'", blocError.ErrDisposed, err)
+	}
+}
+
+func TestBloC_SetScheduler(t *testing.T) {
+	b, c, states := createScheduledBloC(t)
+	defer b.Dispose()
+
+	if err := b.SetScheduler(nil); err == nil {
+		t.Errorf("Expected Error When Changing Scheduler Of Listened BloC")
+	}
+
+	transitions := make([]Transition[Event, State, BD], 0)
+	b.AddTransitionListener(func(NewTransition Transition[Event, State, BD]) {
+		transitions = append(transitions, NewTransition)
+	})
+
+	c.Advance(time.Second)
+	if err := b.AddEvent(Event{Data: 5}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	if value := len(*states); value != 0 {
+		t.Errorf("Expected len(states) Before Flush To Be Of Value '%d' Actual '%d'", 0, value)
+	}
+
+	c.Flush()
+	if value := len(*states); value != 1 {
+		t.Fatalf("Expected len(states) To Be Of Value '%d' Actual '%d'", 1, value)
+	}
+	if value := transitions[0].Event.TimeStamp; value != 1000 {
+		t.Errorf("Expected Event.TimeStamp To Be Of Value '%d' Actual '%d'", 1000, value)
+	}
+	if value := transitions[0].StartedAt; !value.Equal(time.Unix(1, 0)) {
+		t.Errorf("Expected StartedAt To Equal '%s' Actual '%s'", time.Unix(1, 0), value)
+	}
+}
@@ -0,0 +1,149 @@
+package bloc
+
+import (
+	"fmt"
+	"time"
+
+	err "github.com/hijgo/go-bloc/error"
+	"github.com/hijgo/go-bloc/event"
+)
+
+// Describes the change from one state to the next one, caused by a single event.
+//
+// E : Type of events being emitted into the BloC
+//
+// S : Type of states being produced by the BloC from incoming events
+//
+// BD : BloCData Type of data that will be available to function that produces new states
+//
+// Event : The event that caused the transition
+//
+// PreviousState : The state of the BloC before the event was mapped, only valid if HasPreviousState is true
+//
+// HasPreviousState : False if the transition was the first one of the BloC
+//
+// NextState : The state produced by mapping the event
+//
+// BloCData : A copy of the BloCData after the event was mapped. Note that reference types inside of the BloCData, like
+// maps, slices or pointers, will be shared with the BloC
+//
+// StartedAt : Time the mapping of the event started
+//
+// FinishedAt : Time the mapping of the event finished
+type Transition[E any, S any, BD any] struct {
+	Event            event.Event[E]
+	PreviousState    S
+	HasPreviousState bool
+	NextState        S
+	BloCData         BD
+	StartedAt        time.Time
+	FinishedAt       time.Time
+}
+
+// Registers a function that will be called after every transition of the BloC.
+// The function will be called from the goroutine processing the event stream, after the new state was passed to the
+// state stream.
+//
+// OnTransition : Function that will be called with every new transition
+//
+// Will return a function that removes the listener again.
+func (b *BloC[E, S, BD]) AddTransitionListener(OnTransition func(Transition[E, S, BD])) func() {
+	b.core.lock.Lock()
+	defer b.core.lock.Unlock()
+	id := b.core.transitionListeners.add(OnTransition)
+	return func() {
+		b.core.lock.Lock()
+		defer b.core.lock.Unlock()
+		b.core.transitionListeners.remove(id)
+	}
+}
+
+// Returns the current state of the BloC. If no event was mapped yet, will return false as second value.
+//
+// Must not be called from inside mapEventToState.
+func (b *BloC[E, S, BD]) GetState() (S, bool) {
+	b.core.lock.RLock()
+	defer b.core.lock.RUnlock()
+	return b.core.state, b.core.hasState
+}
+
+// Returns a copy of the BloCData currently used by the BloC.
+//
+// Must not be called from inside mapEventToState.
+func (b *BloC[E, S, BD]) GetBloCData() BD {
+	b.core.lock.RLock()
+	defer b.core.lock.RUnlock()
+	return b.core.bloCData
+}
+
+// Creates a new, independent BloC with the same mapEventToState function, starting with the given BloCData and state.
+// The BloC the fork was created from will not be changed.
+//
+// BloCData : The BloCData the new BloC should start with
+//
+// State : The state the new BloC should start with, nil if the new BloC should start without a state
+func (b *BloC[E, S, BD]) Fork(BloCData BD, State *S) BloC[E, S, BD] {
+	return createBloC(BloCData, State, b.mapEventToState, b.mapWithState)
+}
+
+// Replaces the state and BloCData of a BloC that is not listened to yet, for example to start a test from a given
+// state. Unlike Fork, the BloC keeps its middlewares, interceptors, deduplication and effects. Listeners are not informed.
+//
+// BloCData : The BloCData the BloC should start with, nil to keep the current BloCData
+//
+// State : The state the BloC should start with, nil to keep the current state
+//
+// Will return an error if the event stream is currently listened to.
+func (b *BloC[E, S, BD]) Seed(BloCData *BD, State *S) error {
+	if b.eventStream.GetListenStatus() {
+		return &err.Error{
+			Context: "Cannot seed BloC, BloC is listened to!",
+			Err:     fmt.Errorf("stream already listened to"),
+			Code:    err.CodeAlreadyListening,
+		}
+	}
+	b.core.lock.Lock()
+	defer b.core.lock.Unlock()
+	if BloCData != nil {
+		b.core.bloCData = *BloCData
+	}
+	if State != nil {
+		b.core.state, b.core.hasState = *State, true
+	}
+	return nil
+}
+
+// An ordered list of listeners, that can be removed again by their id.
+// Is not safe for concurrent use and must be guarded by the lock of the owner.
+type listeners[T any] struct {
+	entries []listener[T]
+	nextID  int
+}
+
+type listener[T any] struct {
+	id     int
+	notify func(T)
+}
+
+func (l *listeners[T]) add(Notify func(T)) int {
+	l.nextID++
+	l.entries = append(l.entries, listener[T]{id: l.nextID, notify: Notify})
+	return l.nextID
+}
+
+func (l *listeners[T]) remove(ID int) {
+	for i, entry := range l.entries {
+		if entry.id == ID {
+			l.entries = append(l.entries[:i:i], l.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+func (l *listeners[T]) snapshot() []func(T) {
+	notifies := make([]func(T), 0, len(l.entries))
+	for _, entry := range l.entries {
+		notifies = append(notifies, entry.notify)
+	}
+	return notifies
+}
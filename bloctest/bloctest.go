@@ -0,0 +1,263 @@
+package bloctest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/bloc"
+	"github.com/hijgo/go-bloc/clock"
+)
+
+// Default time a single Case may take, before it is reported as timed out.
+var DefaultTimeout = time.Second
+
+// A single test of a BloC: build the BloC, optionally seed its state and BloCData, act by adding events and then
+// assert the emitted states, errors and the final BloCData.
+//
+// The BloC is driven by a clock.Virtual, so all events are mapped deterministically and no WaitGroups are needed.
+// Scheduled events can be triggered by advancing the clock passed to Act.
+//
+// E : Type of events being emitted into the BloC
+//
+// S : Type of states being produced by the BloC from incoming events
+//
+// BD : BloCData Type of data that will be available to function that produces new states
+//
+// Name : The name of the sub test when run by Run
+//
+// Build : Function creating the BloC under test, the BloC must not be listened to yet
+//
+// Seed : The state the BloC should start with, nil to keep the state the BloC was built with. The built BloC itself is
+// seeded, so middlewares, interceptors, deduplication and effects registered in Build still apply
+//
+// SeedBloCData : The BloCData the BloC should start with, nil to keep the BloCData the BloC was built with
+//
+// Events : Events added to the BloC, before Act is called
+//
+// Act : Function adding further events to the BloC or advancing the clock, may be nil
+//
+// Skip : Number of emitted states that should be ignored before comparing them to Expect
+//
+// Expect : The exact sequence of states that should be emitted, nil to not check the states
+//
+// ExpectErrors : The exact sequence of errors returned by AddEvent for Events and by Act, compared with errors.Is or
+// by their message
+//
+// ExpectBloCData : Function verifying the final BloCData, returning an error describing a mismatch, may be nil
+//
+// Equal : Function comparing two states, reflect.DeepEqual if nil
+//
+// Timeout : Time the Case may take, DefaultTimeout if zero
+type Case[E any, S any, BD any] struct {
+	Name           string
+	Build          func() bloc.BloC[E, S, BD]
+	Seed           *S
+	SeedBloCData   *BD
+	Events         []E
+	Act            func(BloC *bloc.BloC[E, S, BD], Clock *clock.Virtual) error
+	Skip           int
+	Expect         []S
+	ExpectErrors   []error
+	ExpectBloCData func(BloCData BD) error
+	Equal          func(a S, b S) bool
+	Timeout        time.Duration
+}
+
+// The outcome of a Case, as observed by Check.
+//
+// States : All states emitted by the BloC, including skipped states
+//
+// Errors : All errors returned by AddEvent for Events and by Act
+//
+// BloCData : The final BloCData of the BloC
+type Result[S any, BD any] struct {
+	States   []S
+	Errors   []error
+	BloCData BD
+}
+
+// Runs every Case as its own sub test.
+//
+// Cases : The cases that should be run
+func Run[E any, S any, BD any](t *testing.T, Cases ...Case[E, S, BD]) {
+	t.Helper()
+	for _, c := range Cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			Check(t, c)
+		})
+	}
+}
+
+// Runs the Case and reports every mismatch to t.
+//
+// t : Receives the failures, usually a *testing.T
+//
+// Case : The case that should be run
+//
+// Will return what was observed while running the case.
+func Check[E any, S any, BD any](t testing.TB, Case Case[E, S, BD]) Result[S, BD] {
+	t.Helper()
+	timeout := Case.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	done := make(chan Result[S, BD], 1)
+	setupErrors := make(chan error, 1)
+	go func() {
+		result, setupErr := run(Case)
+		if setupErr != nil {
+			setupErrors <- setupErr
+			return
+		}
+		done <- result
+	}()
+
+	var result Result[S, BD]
+	select {
+	case result = <-done:
+	case setupErr := <-setupErrors:
+		t.Errorf("Unexpected error occured: %s", setupErr.Error())
+		return result
+	case <-time.After(timeout):
+		t.Errorf("Case timed out after '%s'", timeout)
+		return result
+	}
+
+	if Case.Expect != nil {
+		equal := Case.Equal
+		if equal == nil {
+			equal = func(a S, b S) bool { return reflect.DeepEqual(a, b) }
+		}
+		states := result.States
+		if Case.Skip < len(states) {
+			states = states[Case.Skip:]
+		} else {
+			states = nil
+		}
+		if diff := diffStates(Case.Expect, states, equal); diff != "" {
+			t.Errorf("Expected States To Equal Expect\n%s", diff)
+		}
+	}
+
+	if Case.ExpectErrors != nil {
+		if diff := diffErrors(Case.ExpectErrors, result.Errors); diff != "" {
+			t.Errorf("Expected Errors To Equal ExpectErrors\n%s", diff)
+		}
+	} else {
+		for _, actErr := range result.Errors {
+			t.Errorf("Unexpected error occured: %s", actErr.Error())
+		}
+	}
+
+	if Case.ExpectBloCData != nil {
+		if mismatch := Case.ExpectBloCData(result.BloCData); mismatch != nil {
+			t.Errorf("Expected BloCData To Match: %s\nActual: %+v", mismatch.Error(), result.BloCData)
+		}
+	}
+	return result
+}
+
+func run[E any, S any, BD any](Case Case[E, S, BD]) (Result[S, BD], error) {
+	var result Result[S, BD]
+	if Case.Build == nil {
+		return result, fmt.Errorf("case '%s' has no Build function", Case.Name)
+	}
+
+	b := Case.Build()
+	defer b.Dispose()
+	if seedErr := b.Seed(Case.SeedBloCData, Case.Seed); seedErr != nil {
+		return result, seedErr
+	}
+
+	virtual := clock.CreateVirtualClock(time.Now())
+	b.SetClock(virtual)
+	if schedulerErr := b.SetScheduler(virtual); schedulerErr != nil {
+		return result, schedulerErr
+	}
+	b.AddStateListener(func(NewState S) { result.States = append(result.States, NewState) })
+	if listenErr := b.StartListenToEventStream(); listenErr != nil {
+		return result, listenErr
+	}
+
+	for _, newEvent := range Case.Events {
+		if addErr := b.AddEvent(newEvent); addErr != nil {
+			result.Errors = append(result.Errors, addErr)
+		}
+		virtual.Flush()
+	}
+	if Case.Act != nil {
+		if actErr := Case.Act(&b, virtual); actErr != nil {
+			result.Errors = append(result.Errors, actErr)
+		}
+	}
+	virtual.Flush()
+
+	result.BloCData = b.GetBloCData()
+	return result, nil
+}
+
+func diffStates[S any](Expected []S, Actual []S, Equal func(a S, b S) bool) string {
+	length := len(Expected)
+	if len(Actual) > length {
+		length = len(Actual)
+	}
+	mismatch := false
+	var builder strings.Builder
+	for i := 0; i < length; i++ {
+		expected, actual := "<none>", "<none>"
+		matches := i < len(Expected) && i < len(Actual) && Equal(Expected[i], Actual[i])
+		if i < len(Expected) {
+			expected = fmt.Sprintf("%+v", Expected[i])
+		}
+		if i < len(Actual) {
+			actual = fmt.Sprintf("%+v", Actual[i])
+		}
+		writeDiffLine(&builder, i, matches, expected, actual)
+		mismatch = mismatch || !matches
+	}
+	if !mismatch {
+		return ""
+	}
+	return builder.String()
+}
+
+func diffErrors(Expected []error, Actual []error) string {
+	length := len(Expected)
+	if len(Actual) > length {
+		length = len(Actual)
+	}
+	mismatch := false
+	var builder strings.Builder
+	for i := 0; i < length; i++ {
+		expected, actual := "<none>", "<none>"
+		matches := i < len(Expected) && i < len(Actual) &&
+			(errors.Is(Actual[i], Expected[i]) || Actual[i].Error() == Expected[i].Error())
+		if i < len(Expected) {
+			expected = Expected[i].Error()
+		}
+		if i < len(Actual) {
+			actual = Actual[i].Error()
+		}
+		writeDiffLine(&builder, i, matches, expected, actual)
+		mismatch = mismatch || !matches
+	}
+	if !mismatch {
+		return ""
+	}
+	return builder.String()
+}
+
+func writeDiffLine(Builder *strings.Builder, Index int, Matches bool, Expected string, Actual string) {
+	if Matches {
+		fmt.Fprintf(Builder, "    [%d] %s\n", Index, Expected)
+		return
+	}
+	fmt.Fprintf(Builder, "  - [%d] %s\n", Index, Expected)
+	fmt.Fprintf(Builder, "  + [%d] %s\n", Index, Actual)
+}
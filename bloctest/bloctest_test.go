@@ -0,0 +1,163 @@
+package bloctest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/bloc"
+	"github.com/hijgo/go-bloc/clock"
+	"github.com/hijgo/go-bloc/event"
+)
+
+type Counter struct {
+	Count int
+}
+
+type Changes struct {
+	Total int
+}
+
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(Format string, Args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(Format, Args...))
+}
+
+func buildCounter() bloc.BloC[int, Counter, Changes] {
+	return bloc.CreateBloCWithState(Changes{}, Counter{}, func(CurrentState Counter, NewEvent event.Event[int], BloCData *Changes) Counter {
+		BloCData.Total++
+		return Counter{Count: CurrentState.Count + NewEvent.Data}
+	})
+}
+
+func buildGuardedCounter() bloc.BloC[int, Counter, Changes] {
+	b := buildCounter()
+	b.UseEventMiddleware(func(NewEvent event.Event[int], Next bloc.EventHandler[int]) error {
+		if NewEvent.Data < 0 {
+			return fmt.Errorf("negative event '%d'", NewEvent.Data)
+		}
+		return Next(NewEvent)
+	})
+	return b
+}
+
+func TestRun(t *testing.T) {
+	Run(t,
+		Case[int, Counter, Changes]{
+			Name:   "adds events in order",
+			Build:  buildCounter,
+			Events: []int{1, 2, 3},
+			Expect: []Counter{{Count: 1}, {Count: 3}, {Count: 6}},
+			ExpectBloCData: func(BloCData Changes) error {
+				if BloCData.Total != 3 {
+					return fmt.Errorf("expected Total 3")
+				}
+				return nil
+			},
+		},
+		Case[int, Counter, Changes]{
+			Name:         "seeds state and BloCData",
+			Build:        buildCounter,
+			Seed:         &Counter{Count: 10},
+			SeedBloCData: &Changes{Total: 5},
+			Events:       []int{1},
+			Expect:       []Counter{{Count: 11}},
+			ExpectBloCData: func(BloCData Changes) error {
+				if BloCData.Total != 6 {
+					return fmt.Errorf("expected Total 6")
+				}
+				return nil
+			},
+		},
+		Case[int, Counter, Changes]{
+			Name:   "skips states",
+			Build:  buildCounter,
+			Events: []int{1, 1, 1},
+			Skip:   2,
+			Expect: []Counter{{Count: 3}},
+		},
+		Case[int, Counter, Changes]{
+			Name:  "advances virtual time",
+			Build: buildCounter,
+			Act: func(BloC *bloc.BloC[int, Counter, Changes], Clock *clock.Virtual) error {
+				if _, scheduleErr := BloC.AddEventEvery(2, time.Minute); scheduleErr != nil {
+					return scheduleErr
+				}
+				Clock.Advance(2 * time.Minute)
+				return nil
+			},
+			Expect: []Counter{{Count: 2}, {Count: 4}},
+		},
+		Case[int, Counter, Changes]{
+			Name:         "collects errors",
+			Build:        buildGuardedCounter,
+			Events:       []int{1, -1},
+			Expect:       []Counter{{Count: 1}},
+			ExpectErrors: []error{fmt.Errorf("negative event '-1'")},
+		},
+		Case[int, Counter, Changes]{
+			Name:         "keeps middlewares when seeded",
+			Build:        buildGuardedCounter,
+			Seed:         &Counter{Count: 10},
+			Events:       []int{-1, 1},
+			Expect:       []Counter{{Count: 11}},
+			ExpectErrors: []error{fmt.Errorf("negative event '-1'")},
+		},
+	)
+}
+
+func TestCheck_ShouldReportDiff(t *testing.T) {
+	r := &recorder{}
+	result := Check(r, Case[int, Counter, Changes]{
+		Build:  buildCounter,
+		Events: []int{1, 2},
+		Expect: []Counter{{Count: 1}, {Count: 2}},
+	})
+
+	if value := len(result.States); value != 2 {
+		t.Errorf("Expected len(result.States) To Be Of Value '%d' Actual '%d'", 2, value)
+	}
+	if value := len(r.failures); value != 1 {
+		t.Fatalf("Expected len(failures) To Be Of Value '%d' Actual '%d'", 1, value)
+	}
+	for _, expected := range []string{"    [0] {Count:1}", "  - [1] {Count:2}", "  + [1] {Count:3}"} {
+		if !strings.Contains(r.failures[0], expected) {
+			t.Errorf("Expected Failure To Contain '%s' Actual '%s'", expected, r.failures[0])
+		}
+	}
+}
+
+func TestCheck_ShouldReportUnexpectedErrorsAndTimeouts(t *testing.T) {
+	r := &recorder{}
+	Check(r, Case[int, Counter, Changes]{
+		Build: buildCounter,
+		Act: func(BloC *bloc.BloC[int, Counter, Changes], Clock *clock.Virtual) error {
+			return fmt.Errorf("act failed")
+		},
+	})
+	if value := len(r.failures); value != 1 || !strings.Contains(r.failures[0], "act failed") {
+		t.Errorf("Expected Failure To Contain '%s' Actual '%v'", "act failed", r.failures)
+	}
+
+	r = &recorder{}
+	block := make(chan struct{})
+	defer close(block)
+	Check(r, Case[int, Counter, Changes]{
+		Build:   buildCounter,
+		Timeout: 10 * time.Millisecond,
+		Act: func(BloC *bloc.BloC[int, Counter, Changes], Clock *clock.Virtual) error {
+			<-block
+			return nil
+		},
+	})
+	if value := len(r.failures); value != 1 || !strings.Contains(r.failures[0], "timed out") {
+		t.Errorf("Expected Failure To Contain '%s' Actual '%v'", "timed out", r.failures)
+	}
+}
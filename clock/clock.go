@@ -0,0 +1,36 @@
+package clock
+
+import "time"
+
+// Source of the current time and of timers, can be replaced to control time, for example in tests.
+type Clock interface {
+	// Returns the current time.
+	Now() time.Time
+	// Calls the function in its own goroutine once the delay has elapsed.
+	//
+	// Delay : Time to wait before calling the function
+	//
+	// Func : Function that should be called
+	//
+	// Will return a Timer that can be used to stop the call.
+	AfterFunc(Delay time.Duration, Func func()) Timer
+}
+
+// A pending call created by Clock.AfterFunc.
+type Timer interface {
+	// Prevents the call from happening. Returns false if the call already happened or was stopped before.
+	Stop() bool
+}
+
+// The Clock using the real time of the system.
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(Delay time.Duration, Func func()) Timer {
+	return time.AfterFunc(Delay, Func)
+}
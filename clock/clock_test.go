@@ -0,0 +1,34 @@
+package clock
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReal_Now(t *testing.T) {
+	before := time.Now()
+	now := Real.Now()
+	if now.Before(before) {
+		t.Errorf("Expected Now To Not Be Before '%s' Actual '%s'", before, now)
+	}
+}
+
+func TestReal_AfterFunc(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	Real.AfterFunc(time.Millisecond, wg.Done)
+	wg.Wait()
+
+	called := false
+	timer := Real.AfterFunc(time.Hour, func() { called = true })
+	if !timer.Stop() {
+		t.Errorf("Expected Stop To Return '%t'", true)
+	}
+	if timer.Stop() {
+		t.Errorf("Expected Second Stop To Return '%t'", false)
+	}
+	if called {
+		t.Errorf("Expected Stopped Func To Not Be Called")
+	}
+}
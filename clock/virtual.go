@@ -0,0 +1,153 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Runs tasks, for example the delivery of items passed into a stream.
+type Scheduler interface {
+	// Runs the task at some point after Schedule returned.
+	//
+	// Task : The function that should be run
+	Schedule(Task func())
+}
+
+// A Clock and Scheduler whose time only moves when told to, to test time based behaviour deterministically.
+// Timers and scheduled tasks are never run in their own goroutine, but by the goroutine calling Advance, AdvanceTo or
+// Flush, in the order they are due.
+type Virtual struct {
+	lock     sync.Mutex
+	now      time.Time
+	timers   []*virtualTimer
+	tasks    []func()
+	sequence uint64
+}
+
+type virtualTimer struct {
+	clock    *Virtual
+	at       time.Time
+	sequence uint64
+	call     func()
+}
+
+// Function that should be called if a new Virtual clock is needed.
+//
+// Start : The time the clock starts at
+func CreateVirtualClock(Start time.Time) *Virtual {
+	return &Virtual{now: Start}
+}
+
+// Returns the current virtual time.
+func (v *Virtual) Now() time.Time {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.now
+}
+
+// Registers a function that will be called once the virtual time was advanced by the delay.
+//
+// Delay : Time to wait before calling the function
+//
+// Func : Function that should be called
+func (v *Virtual) AfterFunc(Delay time.Duration, Func func()) Timer {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if Delay < 0 {
+		Delay = 0
+	}
+	v.sequence++
+	timer := &virtualTimer{clock: v, at: v.now.Add(Delay), sequence: v.sequence, call: Func}
+	v.timers = append(v.timers, timer)
+	return timer
+}
+
+func (t *virtualTimer) Stop() bool {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+	for i, timer := range t.clock.timers {
+		if timer == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Queues the task, it will be run by the next call to Flush, Advance or AdvanceTo.
+//
+// Task : The function that should be run
+func (v *Virtual) Schedule(Task func()) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.tasks = append(v.tasks, Task)
+}
+
+// Returns the number of timers and tasks that did not run yet.
+func (v *Virtual) Pending() int {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return len(v.timers) + len(v.tasks)
+}
+
+// Runs all queued tasks, including tasks queued by the tasks themselves, without advancing the time.
+// Timers that are due at the current time are run as well.
+func (v *Virtual) Flush() {
+	v.AdvanceTo(v.Now())
+}
+
+// Advances the virtual time by the duration, running all timers that become due in the order of their due time.
+// Before each timer and at the end all queued tasks are run.
+//
+// Duration : The duration the time should be advanced by
+func (v *Virtual) Advance(Duration time.Duration) {
+	v.AdvanceTo(v.Now().Add(Duration))
+}
+
+// Advances the virtual time to the given time, see Advance. If the time lies in the past, the time stays unchanged,
+// but queued tasks and due timers are still run.
+//
+// Target : The time the clock should be advanced to
+func (v *Virtual) AdvanceTo(Target time.Time) {
+	for {
+		v.runTasks()
+
+		v.lock.Lock()
+		if Target.Before(v.now) {
+			Target = v.now
+		}
+		sort.SliceStable(v.timers, func(a, b int) bool {
+			if v.timers[a].at.Equal(v.timers[b].at) {
+				return v.timers[a].sequence < v.timers[b].sequence
+			}
+			return v.timers[a].at.Before(v.timers[b].at)
+		})
+		if len(v.timers) == 0 || v.timers[0].at.After(Target) {
+			v.now = Target
+			v.lock.Unlock()
+			return
+		}
+		due := v.timers[0]
+		v.timers = v.timers[1:]
+		v.now = due.at
+		v.lock.Unlock()
+
+		due.call()
+	}
+}
+
+func (v *Virtual) runTasks() {
+	for {
+		v.lock.Lock()
+		if len(v.tasks) == 0 {
+			v.lock.Unlock()
+			return
+		}
+		task := v.tasks[0]
+		v.tasks = v.tasks[1:]
+		v.lock.Unlock()
+
+		task()
+	}
+}
@@ -0,0 +1,71 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVirtual_Advance(t *testing.T) {
+	v := CreateVirtualClock(time.Unix(0, 0))
+	calls := make([]string, 0)
+
+	v.AfterFunc(2*time.Second, func() { calls = append(calls, "b") })
+	v.AfterFunc(time.Second, func() {
+		calls = append(calls, "a")
+		v.AfterFunc(0, func() { calls = append(calls, "a2") })
+		v.Schedule(func() { calls = append(calls, "task") })
+	})
+	stopped := v.AfterFunc(time.Second, func() { calls = append(calls, "stopped") })
+	if !stopped.Stop() {
+		t.Errorf("Expected Stop To Return '%t'", true)
+	}
+
+	v.Advance(500 * time.Millisecond)
+	if value := len(calls); value != 0 {
+		t.Errorf("Expected len(calls) To Be Of Value '%d' Actual '%d'", 0, value)
+	}
+
+	v.Advance(2 * time.Second)
+	expected := []string{"a", "task", "a2", "b"}
+	if len(calls) != len(expected) {
+		t.Fatalf("Expected calls To Equal '%v' Actual '%v'", expected, calls)
+	}
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Errorf("Expected calls To Equal '%v' Actual '%v'", expected, calls)
+			break
+		}
+	}
+	if value := v.Now(); !value.Equal(time.Unix(2, 5e8)) {
+		t.Errorf("Expected Now To Equal '%s' Actual '%s'", time.Unix(2, 5e8), value)
+	}
+	if value := v.Pending(); value != 0 {
+		t.Errorf("Expected Pending To Be Of Value '%d' Actual '%d'", 0, value)
+	}
+}
+
+func TestVirtual_Flush(t *testing.T) {
+	v := CreateVirtualClock(time.Unix(10, 0))
+	count := 0
+	v.Schedule(func() {
+		count++
+		v.Schedule(func() { count++ })
+	})
+	v.AfterFunc(time.Second, func() { count++ })
+
+	if value := v.Pending(); value != 2 {
+		t.Errorf("Expected Pending To Be Of Value '%d' Actual '%d'", 2, value)
+	}
+	v.Flush()
+	if count != 2 {
+		t.Errorf("Expected count To Be Of Value '%d' Actual '%d'", 2, count)
+	}
+	if value := v.Now(); !value.Equal(time.Unix(10, 0)) {
+		t.Errorf("Expected Now To Equal '%s' Actual '%s'", time.Unix(10, 0), value)
+	}
+
+	v.AdvanceTo(time.Unix(5, 0))
+	if value := v.Now(); !value.Equal(time.Unix(10, 0)) {
+		t.Errorf("Expected Now To Stay '%s' Actual '%s'", time.Unix(10, 0), value)
+	}
+}
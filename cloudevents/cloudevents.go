@@ -0,0 +1,377 @@
+package cloudevents
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hijgo/go-bloc/codec"
+	err "github.com/hijgo/go-bloc/error"
+	"github.com/hijgo/go-bloc/event"
+)
+
+// The version of the CloudEvents specification implemented by this package.
+const SpecVersion = "1.0"
+
+// Content type of CloudEvents in the structured HTTP mode with JSON format.
+const StructuredContentType = "application/cloudevents+json"
+
+// Content type of JSON encoded data.
+const JSONContentType = "application/json"
+
+// Names of the extension attributes the metadata of an event is mapped to.
+const (
+	CorrelationIDExtension = "correlationid"
+	CausationIDExtension   = "causationid"
+	SequenceExtension      = "sequence"
+	VersionExtension       = "dataversion"
+)
+
+var extensionName = regexp.MustCompile(`^[a-z0-9]{1,20}$`)
+
+var reservedAttributes = map[string]bool{
+	"specversion": true, "id": true, "source": true, "type": true, "subject": true, "time": true,
+	"datacontenttype": true, "dataschema": true, "data": true, "data_base64": true,
+}
+
+// A CloudEvent as described by the CloudEvents 1.0 specification.
+//
+// SpecVersion : The version of the specification, always "1.0"
+//
+// ID : Identifies the event, unique within the scope of Source
+//
+// Source : Identifies the context in which the event happened, as URI-reference
+//
+// Type : Describes the type of the event, for example "com.example.cart.item_added"
+//
+// Subject : Describes the subject of the event in the context of Source, may be empty
+//
+// Time : The time the event happened, may be zero
+//
+// DataContentType : Content type of Data, JSON if empty
+//
+// DataSchema : URI of the schema of Data, may be empty
+//
+// Data : The encoded data of the event, may be empty
+//
+// Extensions : Additional attributes, names must consist of at most 20 lower case letters or digits
+type CloudEvent struct {
+	SpecVersion     string
+	ID              string
+	Source          string
+	Type            string
+	Subject         string
+	Time            time.Time
+	DataContentType string
+	DataSchema      string
+	Data            []byte
+	Extensions      map[string]string
+}
+
+// Returns an error if a required attribute is missing or an attribute is invalid.
+func (c CloudEvent) Validate() error {
+	invalid := func(Reason string) error {
+		return &err.Error{Context: "Invalid CloudEvent!", Err: fmt.Errorf("%s", Reason)}
+	}
+	switch {
+	case c.SpecVersion != SpecVersion:
+		return invalid(fmt.Sprintf("unsupported specversion '%s'", c.SpecVersion))
+	case c.ID == "":
+		return invalid("missing id")
+	case c.Source == "":
+		return invalid("missing source")
+	case c.Type == "":
+		return invalid("missing type")
+	}
+	for name := range c.Extensions {
+		if !extensionName.MatchString(name) || reservedAttributes[name] {
+			return invalid(fmt.Sprintf("invalid extension name '%s'", name))
+		}
+	}
+	return nil
+}
+
+func (c CloudEvent) isJSON() bool {
+	contentType := strings.TrimSpace(strings.Split(c.DataContentType, ";")[0])
+	return contentType == "" || contentType == JSONContentType || strings.HasSuffix(contentType, "+json") ||
+		strings.HasPrefix(contentType, "text/json")
+}
+
+// Encodes the CloudEvent in the JSON format, as used by the structured HTTP mode.
+func (c CloudEvent) MarshalJSON() ([]byte, error) {
+	attributes := make(map[string]any, len(c.Extensions)+8)
+	for name, value := range c.Extensions {
+		attributes[name] = value
+	}
+	attributes["specversion"] = c.SpecVersion
+	attributes["id"] = c.ID
+	attributes["source"] = c.Source
+	attributes["type"] = c.Type
+	setIfNotEmpty := func(Name string, Value string) {
+		if Value != "" {
+			attributes[Name] = Value
+		}
+	}
+	setIfNotEmpty("subject", c.Subject)
+	setIfNotEmpty("datacontenttype", c.DataContentType)
+	setIfNotEmpty("dataschema", c.DataSchema)
+	if !c.Time.IsZero() {
+		attributes["time"] = c.Time.Format(time.RFC3339Nano)
+	}
+	if len(c.Data) > 0 {
+		if c.isJSON() {
+			if !json.Valid(c.Data) {
+				return nil, &err.Error{Context: "Cannot encode CloudEvent!", Err: fmt.Errorf("data is not valid JSON")}
+			}
+			attributes["data"] = json.RawMessage(c.Data)
+		} else {
+			attributes["data_base64"] = base64.StdEncoding.EncodeToString(c.Data)
+		}
+	}
+	return json.Marshal(attributes)
+}
+
+// Decodes a CloudEvent in the JSON format, as used by the structured HTTP mode.
+func (c *CloudEvent) UnmarshalJSON(Data []byte) error {
+	var attributes map[string]json.RawMessage
+	if decodeErr := json.Unmarshal(Data, &attributes); decodeErr != nil {
+		return &err.Error{Context: "Cannot decode CloudEvent!", Err: decodeErr}
+	}
+
+	decoded := CloudEvent{}
+	for name, raw := range attributes {
+		if name == "data" {
+			decoded.Data = append([]byte(nil), raw...)
+			continue
+		}
+		var value any
+		if decodeErr := json.Unmarshal(raw, &value); decodeErr != nil {
+			return &err.Error{Context: "Cannot decode CloudEvent!", Err: decodeErr}
+		}
+		if value == nil {
+			continue
+		}
+		text := fmt.Sprint(value)
+		if number, isNumber := value.(float64); isNumber {
+			text = strconv.FormatFloat(number, 'f', -1, 64)
+		}
+		if setErr := decoded.setAttribute(name, text); setErr != nil {
+			return setErr
+		}
+	}
+	*c = decoded
+	return nil
+}
+
+// Sets a context attribute or extension by its name, as read from the JSON format or binary HTTP headers.
+func (c *CloudEvent) setAttribute(Name string, Value string) error {
+	switch Name {
+	case "specversion":
+		c.SpecVersion = Value
+	case "id":
+		c.ID = Value
+	case "source":
+		c.Source = Value
+	case "type":
+		c.Type = Value
+	case "subject":
+		c.Subject = Value
+	case "datacontenttype":
+		c.DataContentType = Value
+	case "dataschema":
+		c.DataSchema = Value
+	case "time":
+		parsed, parseErr := time.Parse(time.RFC3339Nano, Value)
+		if parseErr != nil {
+			return &err.Error{Context: "Cannot decode CloudEvent!", Err: parseErr}
+		}
+		c.Time = parsed
+	case "data_base64":
+		data, decodeErr := base64.StdEncoding.DecodeString(Value)
+		if decodeErr != nil {
+			return &err.Error{Context: "Cannot decode CloudEvent!", Err: decodeErr}
+		}
+		c.Data = data
+	default:
+		if c.Extensions == nil {
+			c.Extensions = make(map[string]string)
+		}
+		c.Extensions[Name] = Value
+	}
+	return nil
+}
+
+// Converts between event.Event[T] and CloudEvents. The data is encoded as JSON.
+//
+// T : The type of data carried with by the events
+//
+// Source : The source of CloudEvents created from events without event.Metadata Source
+//
+// Type : The type of CloudEvents, the name of T if empty. Ignored if Types is set
+//
+// Types : Registry used to map the concrete type of the data to the type of the CloudEvent and back, nil if T is not
+// an interface
+type Converter[T any] struct {
+	Source string
+	Type   string
+	Types  *codec.Registry
+}
+
+// Function that should be called if a new Converter is needed.
+//
+// T : The type of data carried with by the events
+//
+// Source : The source of CloudEvents created from events without source
+//
+// Type : The type of the CloudEvents, the name of T if empty
+func CreateConverter[T any](Source string, Type string) Converter[T] {
+	return Converter[T]{Source: Source, Type: Type}
+}
+
+func (c Converter[T]) eventType() string {
+	if c.Type != "" {
+		return c.Type
+	}
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+// Converts the event into a CloudEvent. The id, source, time and data of the event are mapped to the corresponding
+// attributes, the correlation id, causation id, sequence, version of the data and headers to extensions. Headers whose names are not valid
+// extension names are dropped.
+//
+// Event : The event that should be converted
+func (c Converter[T]) FromEvent(Event event.Event[T]) (CloudEvent, error) {
+	converted := CloudEvent{
+		SpecVersion:     SpecVersion,
+		ID:              Event.ID,
+		Source:          Event.Source,
+		Type:            c.eventType(),
+		Time:            Event.CreatedAt(),
+		DataContentType: JSONContentType,
+		Extensions:      make(map[string]string),
+	}
+	if converted.Source == "" {
+		converted.Source = c.Source
+	}
+	if converted.ID == "" {
+		converted.ID = event.NewID()
+	}
+
+	if c.Types != nil {
+		envelope, wrapErr := c.Types.Wrap(codec.JSON, Event.Data)
+		if wrapErr != nil {
+			return CloudEvent{}, wrapErr
+		}
+		converted.Type, converted.Data = envelope.Type, envelope.Data
+		converted.Extensions[VersionExtension] = strconv.FormatUint(uint64(envelope.Version), 10)
+	} else {
+		data, encodeErr := codec.JSON.Marshal(Event.Data)
+		if encodeErr != nil {
+			return CloudEvent{}, encodeErr
+		}
+		converted.Data = data
+		if Event.Version != 0 {
+			converted.Extensions[VersionExtension] = strconv.FormatUint(uint64(Event.Version), 10)
+		}
+	}
+
+	for name, value := range Event.Headers.ToMap() {
+		if extensionName.MatchString(name) && !reservedAttributes[name] {
+			converted.Extensions[name] = value
+		}
+	}
+	if Event.CorrelationID != "" {
+		converted.Extensions[CorrelationIDExtension] = Event.CorrelationID
+	}
+	if Event.CausationID != "" {
+		converted.Extensions[CausationIDExtension] = Event.CausationID
+	}
+	if Event.Sequence != 0 {
+		converted.Extensions[SequenceExtension] = strconv.FormatUint(Event.Sequence, 10)
+	}
+	return converted, converted.Validate()
+}
+
+// Converts the CloudEvent into an event, reversing FromEvent. Extensions other than the correlation id, causation id,
+// sequence and version are mapped to headers. If the Converter has a Registry, data of an older version is upcast to
+// the latest version, data without version is treated as latest version.
+//
+// CloudEvent : The CloudEvent that should be converted, its data must be JSON
+//
+// Will return an error if the CloudEvent is invalid, its type does not match or its data cannot be decoded.
+func (c Converter[T]) ToEvent(CloudEvent CloudEvent) (event.Event[T], error) {
+	if validateErr := CloudEvent.Validate(); validateErr != nil {
+		return event.Event[T]{}, validateErr
+	}
+	if !CloudEvent.isJSON() {
+		return event.Event[T]{}, &err.Error{
+			Context: "Cannot convert CloudEvent, only JSON data is supported!",
+			Err:     fmt.Errorf("unsupported datacontenttype '%s'", CloudEvent.DataContentType),
+		}
+	}
+
+	options := []event.Option{event.WithID(CloudEvent.ID), event.WithSource(CloudEvent.Source)}
+	var sequence uint64
+	var version uint
+	for name, value := range CloudEvent.Extensions {
+		switch name {
+		case CorrelationIDExtension:
+			options = append(options, event.WithCorrelationID(value))
+		case CausationIDExtension:
+			options = append(options, event.WithCausationID(value))
+		case SequenceExtension:
+			parsed, parseErr := strconv.ParseUint(value, 10, 64)
+			if parseErr != nil {
+				return event.Event[T]{}, &err.Error{Context: "Cannot convert CloudEvent, invalid sequence!", Err: parseErr}
+			}
+			sequence = parsed
+		case VersionExtension:
+			parsed, parseErr := strconv.ParseUint(value, 10, 32)
+			if parseErr != nil {
+				return event.Event[T]{}, &err.Error{Context: "Cannot convert CloudEvent, invalid version!", Err: parseErr}
+			}
+			version = uint(parsed)
+		default:
+			options = append(options, event.WithHeader(name, value))
+		}
+	}
+
+	var data T
+	if c.Types != nil {
+		if version == 0 {
+			version = c.Types.GetVersion(CloudEvent.Type)
+		}
+		wrapped := codec.Envelope{Type: CloudEvent.Type, Version: version, Data: CloudEvent.Data}
+		unwrapped, unwrapErr := codec.Unwrap[T](c.Types, codec.JSON, wrapped)
+		if unwrapErr != nil {
+			return event.Event[T]{}, unwrapErr
+		}
+		data, version = unwrapped, c.Types.GetVersion(CloudEvent.Type)
+	} else {
+		if CloudEvent.Type != c.eventType() {
+			return event.Event[T]{}, &err.Error{
+				Context: "Cannot convert CloudEvent, type does not match!",
+				Err:     fmt.Errorf("type '%s' is not '%s'", CloudEvent.Type, c.eventType()),
+			}
+		}
+		if len(CloudEvent.Data) > 0 {
+			if decodeErr := codec.JSON.Unmarshal(CloudEvent.Data, &data); decodeErr != nil {
+				return event.Event[T]{}, decodeErr
+			}
+		}
+	}
+
+	createdAt := CloudEvent.Time
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	if version != 0 {
+		options = append(options, event.WithVersion(version))
+	}
+	return event.CreateEventAt(data, createdAt, options...).WithSequence(sequence), nil
+}
@@ -0,0 +1,190 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/codec"
+	"github.com/hijgo/go-bloc/event"
+)
+
+type ItemAdded struct {
+	Item     string `json:"item"`
+	Quantity int    `json:"quantity"`
+}
+
+type CartEvent interface {
+	isCartEvent()
+}
+
+type CartCleared struct {
+	Reason string `json:"reason"`
+}
+
+func (ItemAdded) isCartEvent() {}
+
+func (CartCleared) isCartEvent() {}
+
+func TestConverter_FromEvent(t *testing.T) {
+	converter := CreateConverter[ItemAdded]("/cart", "com.example.cart.item_added")
+	original := event.CreateEventAt(ItemAdded{Item: "apple", Quantity: 2}, time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		event.WithCorrelationID("session"), event.WithCausationID("cause"), event.WithHeader("tenant", "a"),
+		event.WithHeader("Invalid-Name", "dropped")).WithSequence(3)
+
+	converted, err := converter.FromEvent(original)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	expected := CloudEvent{
+		SpecVersion:     SpecVersion,
+		ID:              original.ID,
+		Source:          "/cart",
+		Type:            "com.example.cart.item_added",
+		Time:            original.Time,
+		DataContentType: JSONContentType,
+		Data:            []byte(`{"item":"apple","quantity":2}`),
+		Extensions:      map[string]string{"tenant": "a", "correlationid": "session", "causationid": "cause", "sequence": "3"},
+	}
+	if !reflect.DeepEqual(converted, expected) {
+		t.Errorf("Expected FromEvent To Equal '%+v' Actual '%+v'", expected, converted)
+	}
+
+	back, err := converter.ToEvent(converted)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if back.Data != original.Data || back.ID != original.ID || back.CorrelationID != "session" ||
+		back.CausationID != "cause" || back.Source != "/cart" || back.Sequence != 3 || !back.Time.Equal(original.Time) {
+		t.Errorf("Expected ToEvent To Equal '%+v' Actual '%+v'", original, back)
+	}
+	if value := back.Headers.ToMap(); !reflect.DeepEqual(value, map[string]string{"tenant": "a"}) {
+		t.Errorf("Expected Field Headers To Equal '%v' Actual '%v'", map[string]string{"tenant": "a"}, value)
+	}
+
+	converted.Type = "com.example.other"
+	if _, err := converter.ToEvent(converted); err == nil {
+		t.Errorf("Expected Error For Mismatching Type")
+	}
+}
+
+func TestConverter_Types(t *testing.T) {
+	types := codec.CreateRegistry()
+	if err := codec.Register[ItemAdded](types, "com.example.cart.item_added"); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := codec.Register[CartCleared](types, "com.example.cart.cleared"); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	converter := Converter[CartEvent]{Source: "/cart", Types: types}
+
+	converted, err := converter.FromEvent(event.CreateEvent[CartEvent](CartCleared{Reason: "logout"}))
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if value := converted.Extensions[VersionExtension]; value != "1" {
+		t.Errorf("Expected Extension dataversion To Equal '%s' Actual '%s'", "1", value)
+	}
+	if value := converted.Type; value != "com.example.cart.cleared" {
+		t.Errorf("Expected Field Type To Equal '%s' Actual '%s'", "com.example.cart.cleared", value)
+	}
+
+	back, err := converter.ToEvent(converted)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if value := back.Data; value != (CartCleared{Reason: "logout"}) {
+		t.Errorf("Expected Field Data To Equal '%+v' Actual '%+v'", CartCleared{Reason: "logout"}, value)
+	}
+
+	if err := codec.RegisterUpcaster(types, "com.example.cart.cleared", 1, func(Value struct{}) (CartCleared, error) {
+		return CartCleared{Reason: "unknown"}, nil
+	}); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	converted.Data = []byte(`{}`)
+	upcast, err := converter.ToEvent(converted)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if value := upcast.Data; value != (CartCleared{Reason: "unknown"}) || upcast.Version != 2 {
+		t.Errorf("Expected Upcast Data To Equal '%+v' Actual '%+v' Version '%d'", CartCleared{Reason: "unknown"}, value, upcast.Version)
+	}
+	delete(converted.Extensions, VersionExtension)
+	if _, err := converter.ToEvent(converted); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+}
+
+func TestCloudEvent_JSON(t *testing.T) {
+	structured := []byte(`{
+		"specversion": "1.0",
+		"type": "com.github.pull_request.opened",
+		"source": "https://github.com/cloudevents/spec/pull",
+		"subject": "123",
+		"id": "A234-1234-1234",
+		"time": "2018-04-05T17:31:00Z",
+		"comexampleextension1": "value",
+		"comexampleothervalue": 5,
+		"datacontenttype": "text/xml",
+		"data_base64": "PG11Y2ggd293PSJ4bWwiLz4="
+	}`)
+
+	var decoded CloudEvent
+	if err := json.Unmarshal(structured, &decoded); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	expected := CloudEvent{
+		SpecVersion:     "1.0",
+		ID:              "A234-1234-1234",
+		Source:          "https://github.com/cloudevents/spec/pull",
+		Type:            "com.github.pull_request.opened",
+		Subject:         "123",
+		Time:            time.Date(2018, 4, 5, 17, 31, 0, 0, time.UTC),
+		DataContentType: "text/xml",
+		Data:            []byte(`<much wow="xml"/>`),
+		Extensions:      map[string]string{"comexampleextension1": "value", "comexampleothervalue": "5"},
+	}
+	if !reflect.DeepEqual(decoded, expected) {
+		t.Errorf("Expected Decoded To Equal '%+v' Actual '%+v'", expected, decoded)
+	}
+
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	var again CloudEvent
+	if err := json.Unmarshal(encoded, &again); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if !reflect.DeepEqual(again, expected) {
+		t.Errorf("Expected Encoded To Round Trip '%+v' Actual '%+v'", expected, again)
+	}
+
+	converter := CreateConverter[ItemAdded]("/cart", "")
+	if _, err := converter.ToEvent(decoded); err == nil {
+		t.Errorf("Expected Error For Non JSON Data")
+	}
+}
+
+func TestCloudEvent_Validate(t *testing.T) {
+	valid := CloudEvent{SpecVersion: SpecVersion, ID: "1", Source: "/s", Type: "t"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+
+	invalid := []CloudEvent{
+		{ID: "1", Source: "/s", Type: "t"},
+		{SpecVersion: SpecVersion, Source: "/s", Type: "t"},
+		{SpecVersion: SpecVersion, ID: "1", Type: "t"},
+		{SpecVersion: SpecVersion, ID: "1", Source: "/s"},
+		{SpecVersion: SpecVersion, ID: "1", Source: "/s", Type: "t", Extensions: map[string]string{"Upper": "x"}},
+		{SpecVersion: SpecVersion, ID: "1", Source: "/s", Type: "t", Extensions: map[string]string{"subject": "x"}},
+	}
+	for _, c := range invalid {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Expected Error For '%+v'", c)
+		}
+	}
+}
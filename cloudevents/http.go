@@ -0,0 +1,265 @@
+package cloudevents
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hijgo/go-bloc/bloc"
+	err "github.com/hijgo/go-bloc/error"
+	"github.com/hijgo/go-bloc/event"
+)
+
+// The HTTP content mode of a CloudEvent.
+type Mode int
+
+const (
+	// The whole CloudEvent is sent as JSON body.
+	StructuredMode Mode = iota
+	// The attributes are sent as ce- prefixed headers and the data as body.
+	BinaryMode
+)
+
+const headerPrefix = "Ce-"
+
+// Maximum number of bytes of a request body read by FromRequest and Handler, larger requests are rejected.
+var MaxBodySize int64 = 1 << 20
+
+// Encodes the CloudEvent for HTTP in the given mode.
+//
+// CloudEvent : The CloudEvent that should be encoded
+//
+// Mode : The content mode
+//
+// Will return the headers and the body, or an error if the CloudEvent is invalid.
+func Encode(CloudEvent CloudEvent, Mode Mode) (http.Header, []byte, error) {
+	if validateErr := CloudEvent.Validate(); validateErr != nil {
+		return nil, nil, validateErr
+	}
+	header := make(http.Header)
+	if Mode == StructuredMode {
+		body, encodeErr := json.Marshal(CloudEvent)
+		if encodeErr != nil {
+			return nil, nil, &err.Error{Context: "Cannot encode CloudEvent!", Err: encodeErr}
+		}
+		header.Set("Content-Type", StructuredContentType)
+		return header, body, nil
+	}
+
+	setHeader := func(Name string, Value string) {
+		if Value != "" {
+			header.Set(headerPrefix+Name, encodeHeaderValue(Value))
+		}
+	}
+	setHeader("specversion", CloudEvent.SpecVersion)
+	setHeader("id", CloudEvent.ID)
+	setHeader("source", CloudEvent.Source)
+	setHeader("type", CloudEvent.Type)
+	setHeader("subject", CloudEvent.Subject)
+	setHeader("dataschema", CloudEvent.DataSchema)
+	if !CloudEvent.Time.IsZero() {
+		setHeader("time", CloudEvent.Time.Format(time.RFC3339Nano))
+	}
+	for name, value := range CloudEvent.Extensions {
+		setHeader(name, value)
+	}
+	if CloudEvent.DataContentType != "" {
+		header.Set("Content-Type", CloudEvent.DataContentType)
+	}
+	return header, CloudEvent.Data, nil
+}
+
+// Decodes a CloudEvent received over HTTP, detecting the content mode by the content type.
+//
+// Header : The headers of the request or response
+//
+// Body : The body of the request or response
+//
+// Will return an error if the CloudEvent cannot be decoded or is invalid.
+func Decode(Header http.Header, Body []byte) (CloudEvent, error) {
+	var decoded CloudEvent
+	contentType := strings.TrimSpace(strings.Split(Header.Get("Content-Type"), ";")[0])
+	if strings.HasPrefix(contentType, "application/cloudevents") {
+		if contentType != StructuredContentType {
+			return decoded, &err.Error{
+				Context: "Cannot decode CloudEvent, only the JSON format is supported!",
+				Err:     fmt.Errorf("unsupported content type '%s'", contentType),
+			}
+		}
+		if decodeErr := json.Unmarshal(Body, &decoded); decodeErr != nil {
+			return decoded, decodeErr
+		}
+		return decoded, decoded.Validate()
+	}
+
+	for name, values := range Header {
+		if !strings.HasPrefix(name, headerPrefix) || len(values) == 0 {
+			continue
+		}
+		value, decodeErr := url.PathUnescape(values[0])
+		if decodeErr != nil {
+			return decoded, &err.Error{Context: "Cannot decode CloudEvent!", Err: decodeErr}
+		}
+		if setErr := decoded.setAttribute(strings.ToLower(strings.TrimPrefix(name, headerPrefix)), value); setErr != nil {
+			return decoded, setErr
+		}
+	}
+	decoded.DataContentType = Header.Get("Content-Type")
+	if len(Body) > 0 {
+		decoded.Data = Body
+	}
+	return decoded, decoded.Validate()
+}
+
+// Percent-encodes all characters not allowed in a header value by the HTTP protocol binding.
+func encodeHeaderValue(Value string) string {
+	var builder strings.Builder
+	for _, b := range []byte(Value) {
+		if b <= ' ' || b > '~' || b == '"' || b == '%' {
+			fmt.Fprintf(&builder, "%%%02X", b)
+			continue
+		}
+		builder.WriteByte(b)
+	}
+	return builder.String()
+}
+
+// Creates a new HTTP request carrying the CloudEvent.
+//
+// Method : The HTTP method, usually POST
+//
+// URL : The URL the request should be sent to
+//
+// CloudEvent : The CloudEvent that should be sent
+//
+// Mode : The content mode
+func NewRequest(Method string, URL string, CloudEvent CloudEvent, Mode Mode) (*http.Request, error) {
+	header, body, encodeErr := Encode(CloudEvent, Mode)
+	if encodeErr != nil {
+		return nil, encodeErr
+	}
+	request, requestErr := http.NewRequest(Method, URL, bytes.NewReader(body))
+	if requestErr != nil {
+		return nil, requestErr
+	}
+	for name, values := range header {
+		request.Header[name] = values
+	}
+	return request, nil
+}
+
+// Reads the CloudEvent carried by the HTTP request, in either content mode.
+//
+// Request : The received request
+//
+// Will return an error matching error.ErrTooLarge if the body is larger than MaxBodySize.
+func FromRequest(Request *http.Request) (CloudEvent, error) {
+	return readRequest(nil, Request)
+}
+
+// Counts the bytes read from the body, so a body exceeding the limit of http.MaxBytesReader can be told apart from
+// other read errors.
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (c *countingReader) Read(Buffer []byte) (int, error) {
+	n, readErr := c.reader.Read(Buffer)
+	c.count += int64(n)
+	return n, readErr
+}
+
+func readRequest(w http.ResponseWriter, Request *http.Request) (CloudEvent, error) {
+	limit := MaxBodySize
+	counter := &countingReader{reader: Request.Body}
+	body, readErr := io.ReadAll(http.MaxBytesReader(w, io.NopCloser(counter), limit))
+	if readErr != nil && counter.count > limit {
+		return CloudEvent{}, &err.Error{
+			Context: "Cannot read CloudEvent, body is too large!",
+			Err:     fmt.Errorf("body exceeds %d bytes", limit),
+			Code:    err.CodeTooLarge,
+		}
+	}
+	if readErr != nil {
+		return CloudEvent{}, &err.Error{Context: "Cannot read CloudEvent!", Err: readErr}
+	}
+	return Decode(Request.Header, body)
+}
+
+// Returns an http.Handler adding every CloudEvent posted to it to the BloC, keeping the id, source, correlation,
+// causation and time of the CloudEvent. CloudEvents without time are created at the time of the clock of the BloC.
+// Responds with 202 Accepted once the event was added and with 413 Request Entity Too
+// Large if the body is larger than MaxBodySize.
+//
+// BloC : The BloC the events should be added to
+//
+// Converter : Converts the CloudEvents into events of the BloC
+func Handler[E any, S any, BD any](BloC *bloc.BloC[E, S, BD], Converter Converter[E]) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method '%s' not allowed", r.Method))
+			return
+		}
+		received, decodeErr := readRequest(w, r)
+		if errors.Is(decodeErr, err.ErrTooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, decodeErr)
+			return
+		}
+		if decodeErr != nil {
+			writeError(w, http.StatusBadRequest, decodeErr)
+			return
+		}
+		newEvent, convertErr := Converter.ToEvent(received)
+		if convertErr != nil {
+			writeError(w, http.StatusBadRequest, convertErr)
+			return
+		}
+		options := []event.Option{event.WithMetadata(newEvent.Metadata)}
+		if !received.Time.IsZero() {
+			options = append(options, event.WithCreatedAt(received.Time))
+		}
+		if addErr := BloC.AddEvent(newEvent.Data, options...); addErr != nil {
+			writeError(w, http.StatusUnprocessableEntity, addErr)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+// Publishes every event of the BloC that resulted in a transition as CloudEvent.
+//
+// BloC : The BloC whose events should be published
+//
+// Converter : Converts the events of the BloC into CloudEvents
+//
+// Send : Function sending the CloudEvent, for example using NewRequest. Called from the goroutine processing the event
+// stream
+//
+// OnError : Function called with errors of the conversion or of Send, may be nil
+//
+// Will return a function that stops publishing.
+func Publish[E any, S any, BD any](BloC *bloc.BloC[E, S, BD], Converter Converter[E], Send func(CloudEvent CloudEvent) error, OnError func(error)) func() {
+	return BloC.AddTransitionListener(func(Transition bloc.Transition[E, S, BD]) {
+		converted, convertErr := Converter.FromEvent(Transition.Event)
+		if convertErr == nil {
+			convertErr = Send(converted)
+		}
+		if convertErr != nil && OnError != nil {
+			OnError(convertErr)
+		}
+	})
+}
+
+func writeError(w http.ResponseWriter, Status int, Err error) {
+	data, _ := json.Marshal(map[string]string{"error": Err.Error()})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(Status)
+	_, _ = w.Write(data)
+}
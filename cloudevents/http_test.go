@@ -0,0 +1,222 @@
+package cloudevents
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/bloc"
+	"github.com/hijgo/go-bloc/clock"
+	blocError "github.com/hijgo/go-bloc/error"
+	"github.com/hijgo/go-bloc/event"
+)
+
+type Cart struct {
+	Items int
+}
+
+func TestEncodeDecode(t *testing.T) {
+	original := CloudEvent{
+		SpecVersion:     SpecVersion,
+		ID:              "1",
+		Source:          "/cart",
+		Type:            "com.example.cart.item_added",
+		Subject:         "cart 42",
+		Time:            time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		DataContentType: JSONContentType,
+		Data:            []byte(`{"item":"apple","quantity":1}`),
+		Extensions:      map[string]string{"tenant": "a \"b\" 100%"},
+	}
+
+	for _, mode := range []Mode{StructuredMode, BinaryMode} {
+		header, body, err := Encode(original, mode)
+		if err != nil {
+			t.Fatalf("Unexpected error occured: %s", err.Error())
+		}
+		decoded, err := Decode(header, body)
+		if err != nil {
+			t.Fatalf("Unexpected error occured: %s", err.Error())
+		}
+		if !reflect.DeepEqual(decoded, original) {
+			t.Errorf("Expected Decoded In Mode '%d' To Equal '%+v' Actual '%+v'", mode, original, decoded)
+		}
+	}
+
+	header, _, _ := Encode(original, BinaryMode)
+	if value := header.Get("Ce-Subject"); value != "cart%2042" {
+		t.Errorf("Expected Header Ce-Subject To Equal '%s' Actual '%s'", "cart%2042", value)
+	}
+	if value := header.Get("Content-Type"); value != JSONContentType {
+		t.Errorf("Expected Header Content-Type To Equal '%s' Actual '%s'", JSONContentType, value)
+	}
+
+	if _, _, err := Encode(CloudEvent{}, StructuredMode); err == nil {
+		t.Errorf("Expected Error For Invalid CloudEvent")
+	}
+	unsupported := http.Header{"Content-Type": []string{"application/cloudevents+xml"}}
+	if _, err := Decode(unsupported, nil); err == nil {
+		t.Errorf("Expected Error For Unsupported Format")
+	}
+}
+
+func createCartBloC(t *testing.T) (bloc.BloC[ItemAdded, Cart, struct{}], *clock.Virtual) {
+	v := clock.CreateVirtualClock(time.Unix(0, 0))
+	b := bloc.CreateBloCWithState(struct{}{}, Cart{}, func(CurrentState Cart, NewEvent event.Event[ItemAdded], _ *struct{}) Cart {
+		return Cart{Items: CurrentState.Items + NewEvent.Data.Quantity}
+	})
+	if err := b.SetScheduler(v); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := b.StartListenToEventStream(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	return b, v
+}
+
+func TestHandler(t *testing.T) {
+	b, v := createCartBloC(t)
+	defer b.Dispose()
+	converter := CreateConverter[ItemAdded]("/cart", "com.example.cart.item_added")
+	server := httptest.NewServer(Handler(&b, converter))
+	defer server.Close()
+
+	incoming := CloudEvent{
+		SpecVersion: SpecVersion,
+		ID:          "external-1",
+		Source:      "/shop",
+		Type:        "com.example.cart.item_added",
+		Time:        time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Data:        []byte(`{"item":"apple","quantity":3}`),
+		Extensions:  map[string]string{"correlationid": "order-7"},
+	}
+	for _, mode := range []Mode{StructuredMode, BinaryMode} {
+		request, err := NewRequest(http.MethodPost, server.URL, incoming, mode)
+		if err != nil {
+			t.Fatalf("Unexpected error occured: %s", err.Error())
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("Unexpected error occured: %s", err.Error())
+		}
+		_ = response.Body.Close()
+		if value := response.StatusCode; value != http.StatusAccepted {
+			t.Errorf("Expected StatusCode To Equal '%d' Actual '%d'", http.StatusAccepted, value)
+		}
+	}
+	v.Flush()
+
+	if state, _ := b.GetState(); state.Items != 6 {
+		t.Errorf("Expected Items To Equal '%d' Actual '%d'", 6, state.Items)
+	}
+	history := b.GetEventHistory()
+	if value := history[0]; value.ID != "external-1" || value.Source != "/shop" || value.CorrelationID != "order-7" {
+		t.Errorf("Expected Metadata Of CloudEvent To Be Kept Actual '%+v'", value.Metadata)
+	}
+	if value := history[0].CreatedAt(); !value.Equal(incoming.Time) {
+		t.Errorf("Expected CreatedAt To Equal '%s' Actual '%s'", incoming.Time, value)
+	}
+
+	response, err := http.Post(server.URL, StructuredContentType, strings.NewReader(`{"specversion":"1.0"}`))
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	_ = response.Body.Close()
+	if value := response.StatusCode; value != http.StatusBadRequest {
+		t.Errorf("Expected StatusCode To Equal '%d' Actual '%d'", http.StatusBadRequest, value)
+	}
+}
+
+func TestHandler_ShouldRejectTooLargeBody(t *testing.T) {
+	b, v := createCartBloC(t)
+	defer b.Dispose()
+	server := httptest.NewServer(Handler(&b, CreateConverter[ItemAdded]("/cart", "com.example.cart.item_added")))
+	defer server.Close()
+	defer func(Previous int64) { MaxBodySize = Previous }(MaxBodySize)
+	MaxBodySize = 256
+
+	incoming := CloudEvent{
+		SpecVersion: SpecVersion,
+		ID:          "external-1",
+		Source:      "/shop",
+		Type:        "com.example.cart.item_added",
+		Data:        []byte(`{"item":"` + strings.Repeat("a", 512) + `","quantity":3}`),
+	}
+	request, err := NewRequest(http.MethodPost, server.URL, incoming, StructuredMode)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if _, err := FromRequest(request); !errors.Is(err, blocError.ErrTooLarge) {
+		t.Errorf("Expected FromRequest To Return Error '%s' Actual '%v'", blocError.ErrTooLarge, err)
+	}
+
+	request, _ = NewRequest(http.MethodPost, server.URL, incoming, StructuredMode)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	_ = response.Body.Close()
+	if value := response.StatusCode; value != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected StatusCode To Equal '%d' Actual '%d'", http.StatusRequestEntityTooLarge, value)
+	}
+	v.Flush()
+	if value := len(b.GetEventHistory()); value != 0 {
+		t.Errorf("Expected len(GetEventHistory) To Equal '%d' Actual '%d'", 0, value)
+	}
+
+	incoming.Data = []byte(`{"item":"apple","quantity":3}`)
+	request, _ = NewRequest(http.MethodPost, server.URL, incoming, StructuredMode)
+	response, err = http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	_ = response.Body.Close()
+	if value := response.StatusCode; value != http.StatusAccepted {
+		t.Errorf("Expected StatusCode To Equal '%d' Actual '%d'", http.StatusAccepted, value)
+	}
+}
+
+func TestPublish(t *testing.T) {
+	b, v := createCartBloC(t)
+	defer b.Dispose()
+	converter := CreateConverter[ItemAdded]("/cart", "com.example.cart.item_added")
+
+	published := make([]CloudEvent, 0)
+	failures := make([]error, 0)
+	stop := Publish(&b, converter, func(CloudEvent CloudEvent) error {
+		published = append(published, CloudEvent)
+		if len(published) > 1 {
+			return fmt.Errorf("broker unavailable")
+		}
+		return nil
+	}, func(Err error) { failures = append(failures, Err) })
+
+	for i := 0; i < 2; i++ {
+		if err := b.AddEvent(ItemAdded{Item: "pear", Quantity: 1}, event.WithSource("/checkout")); err != nil {
+			t.Errorf("Unexpected error occured: %s", err.Error())
+		}
+	}
+	v.Flush()
+	stop()
+	if err := b.AddEvent(ItemAdded{Item: "pear", Quantity: 1}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	v.Flush()
+
+	if value := len(published); value != 2 {
+		t.Fatalf("Expected len(published) To Equal '%d' Actual '%d'", 2, value)
+	}
+	if value := published[0].Source; value != "/checkout" {
+		t.Errorf("Expected Field Source To Equal '%s' Actual '%s'", "/checkout", value)
+	}
+	if value := published[1].Extensions[SequenceExtension]; value != "2" {
+		t.Errorf("Expected Extension sequence To Equal '%s' Actual '%s'", "2", value)
+	}
+	if value := len(failures); value != 1 {
+		t.Errorf("Expected len(failures) To Equal '%d' Actual '%d'", 1, value)
+	}
+}
@@ -0,0 +1,656 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	cborUnsigned byte = iota
+	cborNegative
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+const (
+	cborFalse     = 0xf4
+	cborTrue      = 0xf5
+	cborNull      = 0xf6
+	cborFloat16   = 0xf9
+	cborFloat32   = 0xfa
+	cborFloat64   = 0xfb
+	cborTimeTag   = 0
+	cborMaxLength = 1 << 30
+	cborMaxDepth  = 1000
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func encodeCBOR(Buffer *bytes.Buffer, Value any) error {
+	return encodeCBORValue(Buffer, reflect.ValueOf(Value))
+}
+
+func writeCBORHeader(Buffer *bytes.Buffer, Major byte, Argument uint64) {
+	switch {
+	case Argument < 24:
+		Buffer.WriteByte(Major<<5 | byte(Argument))
+	case Argument <= math.MaxUint8:
+		Buffer.WriteByte(Major<<5 | 24)
+		Buffer.WriteByte(byte(Argument))
+	case Argument <= math.MaxUint16:
+		Buffer.WriteByte(Major<<5 | 25)
+		_ = binary.Write(Buffer, binary.BigEndian, uint16(Argument))
+	case Argument <= math.MaxUint32:
+		Buffer.WriteByte(Major<<5 | 26)
+		_ = binary.Write(Buffer, binary.BigEndian, uint32(Argument))
+	default:
+		Buffer.WriteByte(Major<<5 | 27)
+		_ = binary.Write(Buffer, binary.BigEndian, Argument)
+	}
+}
+
+func encodeCBORValue(Buffer *bytes.Buffer, Value reflect.Value) error {
+	if !Value.IsValid() {
+		Buffer.WriteByte(cborNull)
+		return nil
+	}
+	if Value.Type() == timeType {
+		writeCBORHeader(Buffer, cborTag, cborTimeTag)
+		text := Value.Interface().(time.Time).Format(time.RFC3339Nano)
+		writeCBORHeader(Buffer, cborText, uint64(len(text)))
+		Buffer.WriteString(text)
+		return nil
+	}
+
+	switch Value.Kind() {
+	case reflect.Bool:
+		if Value.Bool() {
+			Buffer.WriteByte(cborTrue)
+		} else {
+			Buffer.WriteByte(cborFalse)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if number := Value.Int(); number < 0 {
+			writeCBORHeader(Buffer, cborNegative, uint64(-(number + 1)))
+		} else {
+			writeCBORHeader(Buffer, cborUnsigned, uint64(number))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeCBORHeader(Buffer, cborUnsigned, Value.Uint())
+	case reflect.Float32:
+		Buffer.WriteByte(cborFloat32)
+		_ = binary.Write(Buffer, binary.BigEndian, math.Float32bits(float32(Value.Float())))
+	case reflect.Float64:
+		Buffer.WriteByte(cborFloat64)
+		_ = binary.Write(Buffer, binary.BigEndian, math.Float64bits(Value.Float()))
+	case reflect.String:
+		writeCBORHeader(Buffer, cborText, uint64(Value.Len()))
+		Buffer.WriteString(Value.String())
+	case reflect.Slice, reflect.Array:
+		if Value.Kind() == reflect.Slice && Value.IsNil() {
+			Buffer.WriteByte(cborNull)
+			return nil
+		}
+		if Value.Type().Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, Value.Len())
+			reflect.Copy(reflect.ValueOf(data), Value)
+			writeCBORHeader(Buffer, cborBytes, uint64(len(data)))
+			Buffer.Write(data)
+			return nil
+		}
+		writeCBORHeader(Buffer, cborArray, uint64(Value.Len()))
+		for i := 0; i < Value.Len(); i++ {
+			if encodeErr := encodeCBORValue(Buffer, Value.Index(i)); encodeErr != nil {
+				return encodeErr
+			}
+		}
+	case reflect.Map:
+		if Value.IsNil() {
+			Buffer.WriteByte(cborNull)
+			return nil
+		}
+		return encodeCBORMap(Buffer, Value)
+	case reflect.Struct:
+		return encodeCBORStruct(Buffer, Value)
+	case reflect.Pointer, reflect.Interface:
+		if Value.IsNil() {
+			Buffer.WriteByte(cborNull)
+			return nil
+		}
+		return encodeCBORValue(Buffer, Value.Elem())
+	default:
+		return fmt.Errorf("unsupported type '%s'", Value.Type())
+	}
+	return nil
+}
+
+// Encodes the entries sorted by their encoded keys, so equal maps always result in equal bytes.
+func encodeCBORMap(Buffer *bytes.Buffer, Value reflect.Value) error {
+	type entry struct {
+		key   []byte
+		value reflect.Value
+	}
+	entries := make([]entry, 0, Value.Len())
+	iterator := Value.MapRange()
+	for iterator.Next() {
+		var key bytes.Buffer
+		if encodeErr := encodeCBORValue(&key, iterator.Key()); encodeErr != nil {
+			return encodeErr
+		}
+		entries = append(entries, entry{key: key.Bytes(), value: iterator.Value()})
+	}
+	sort.Slice(entries, func(a, b int) bool { return bytes.Compare(entries[a].key, entries[b].key) < 0 })
+
+	writeCBORHeader(Buffer, cborMap, uint64(len(entries)))
+	for _, e := range entries {
+		Buffer.Write(e.key)
+		if encodeErr := encodeCBORValue(Buffer, e.value); encodeErr != nil {
+			return encodeErr
+		}
+	}
+	return nil
+}
+
+func encodeCBORStruct(Buffer *bytes.Buffer, Value reflect.Value) error {
+	fields := cborFields(Value.Type())
+	writeCBORHeader(Buffer, cborMap, uint64(len(fields)))
+	for _, field := range fields {
+		writeCBORHeader(Buffer, cborText, uint64(len(field.name)))
+		Buffer.WriteString(field.name)
+		if encodeErr := encodeCBORValue(Buffer, Value.Field(field.index)); encodeErr != nil {
+			return encodeErr
+		}
+	}
+	return nil
+}
+
+type cborField struct {
+	name  string
+	index int
+}
+
+func cborFields(Type reflect.Type) []cborField {
+	fields := make([]cborField, 0, Type.NumField())
+	for i := 0; i < Type.NumField(); i++ {
+		field := Type.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag, hasTag := field.Tag.Lookup("cbor"); hasTag {
+			if tag == "-" {
+				continue
+			}
+			if tagName := strings.Split(tag, ",")[0]; tagName != "" {
+				name = tagName
+			}
+		}
+		fields = append(fields, cborField{name: name, index: i})
+	}
+	return fields
+}
+
+type cborDecoder struct {
+	data     []byte
+	position int
+	depth    int
+}
+
+// Counts a nested item, so deeply nested input returns an error instead of exhausting the stack.
+func (d *cborDecoder) enter() error {
+	d.depth++
+	if d.depth > cborMaxDepth {
+		return fmt.Errorf("nesting exceeds maximum depth of %d", cborMaxDepth)
+	}
+	return nil
+}
+
+func (d *cborDecoder) leave() {
+	d.depth--
+}
+
+func decodeCBOR(Data []byte, Value any) error {
+	target := reflect.ValueOf(Value)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return fmt.Errorf("cannot decode into non pointer '%T'", Value)
+	}
+	d := &cborDecoder{data: Data}
+	if decodeErr := d.decode(target.Elem()); decodeErr != nil {
+		return decodeErr
+	}
+	if d.position != len(d.data) {
+		return fmt.Errorf("unexpected %d trailing bytes", len(d.data)-d.position)
+	}
+	return nil
+}
+
+func (d *cborDecoder) read(Length uint64) ([]byte, error) {
+	if Length > uint64(len(d.data)-d.position) {
+		return nil, fmt.Errorf("unexpected end of data")
+	}
+	data := d.data[d.position : d.position+int(Length)]
+	d.position += int(Length)
+	return data, nil
+}
+
+func (d *cborDecoder) peek() (byte, error) {
+	if d.position >= len(d.data) {
+		return 0, fmt.Errorf("unexpected end of data")
+	}
+	return d.data[d.position], nil
+}
+
+// Reads the major type and argument of the next item. For simple values and floats, the argument holds the raw bits.
+func (d *cborDecoder) header() (byte, byte, uint64, error) {
+	initial, readErr := d.read(1)
+	if readErr != nil {
+		return 0, 0, 0, readErr
+	}
+	major, additional := initial[0]>>5, initial[0]&0x1f
+	if additional < 24 {
+		return major, additional, uint64(additional), nil
+	}
+	if additional > 27 {
+		return 0, 0, 0, fmt.Errorf("unsupported additional information '%d'", additional)
+	}
+	argument, readErr := d.read(1 << (additional - 24))
+	if readErr != nil {
+		return 0, 0, 0, readErr
+	}
+	var value uint64
+	for _, b := range argument {
+		value = value<<8 | uint64(b)
+	}
+	return major, additional, value, nil
+}
+
+func (d *cborDecoder) length(Argument uint64) (int, error) {
+	if Argument > cborMaxLength || Argument > uint64(len(d.data)) {
+		return 0, fmt.Errorf("invalid length '%d'", Argument)
+	}
+	return int(Argument), nil
+}
+
+func (d *cborDecoder) decode(Target reflect.Value) error {
+	if depthErr := d.enter(); depthErr != nil {
+		return depthErr
+	}
+	defer d.leave()
+
+	next, peekErr := d.peek()
+	if peekErr != nil {
+		return peekErr
+	}
+	if next == cborNull {
+		d.position++
+		Target.Set(reflect.Zero(Target.Type()))
+		return nil
+	}
+
+	switch {
+	case Target.Kind() == reflect.Pointer:
+		if Target.IsNil() {
+			Target.Set(reflect.New(Target.Type().Elem()))
+		}
+		return d.decode(Target.Elem())
+	case Target.Kind() == reflect.Interface && Target.NumMethod() == 0:
+		value, decodeErr := d.decodeAny()
+		if decodeErr != nil {
+			return decodeErr
+		}
+		if value != nil {
+			Target.Set(reflect.ValueOf(value))
+		}
+		return nil
+	case Target.Type() == timeType:
+		value, decodeErr := d.decodeAny()
+		if decodeErr != nil {
+			return decodeErr
+		}
+		t, isTime := value.(time.Time)
+		if !isTime {
+			return typeError(fmt.Sprintf("'%T'", value), Target.Interface())
+		}
+		Target.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	major, additional, argument, headerErr := d.header()
+	if headerErr != nil {
+		return headerErr
+	}
+
+	switch Target.Kind() {
+	case reflect.Bool:
+		if major != cborSimple || (argument != cborTrue&0x1f && argument != cborFalse&0x1f) {
+			return typeError("non boolean", Target.Interface())
+		}
+		Target.SetBool(argument == cborTrue&0x1f)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, numberErr := signed(major, argument)
+		if numberErr != nil {
+			return numberErr
+		}
+		if Target.OverflowInt(number) {
+			return fmt.Errorf("number '%d' overflows '%s'", number, Target.Type())
+		}
+		Target.SetInt(number)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if major != cborUnsigned {
+			return typeError("non unsigned number", Target.Interface())
+		}
+		if Target.OverflowUint(argument) {
+			return fmt.Errorf("number '%d' overflows '%s'", argument, Target.Type())
+		}
+		Target.SetUint(argument)
+	case reflect.Float32, reflect.Float64:
+		number, numberErr := float(major, additional, argument)
+		if numberErr != nil {
+			return numberErr
+		}
+		Target.SetFloat(number)
+	case reflect.String:
+		if major != cborText {
+			return typeError("non text", Target.Interface())
+		}
+		text, readErr := d.readLength(argument)
+		if readErr != nil {
+			return readErr
+		}
+		Target.SetString(string(text))
+	case reflect.Slice, reflect.Array:
+		return d.decodeSequence(Target, major, argument)
+	case reflect.Map:
+		if major != cborMap {
+			return typeError("non map", Target.Interface())
+		}
+		return d.decodeMap(Target, argument)
+	case reflect.Struct:
+		if major != cborMap {
+			return typeError("non map", Target.Interface())
+		}
+		return d.decodeStruct(Target, argument)
+	default:
+		return fmt.Errorf("unsupported type '%s'", Target.Type())
+	}
+	return nil
+}
+
+func (d *cborDecoder) readLength(Argument uint64) ([]byte, error) {
+	length, lengthErr := d.length(Argument)
+	if lengthErr != nil {
+		return nil, lengthErr
+	}
+	return d.read(uint64(length))
+}
+
+func (d *cborDecoder) decodeSequence(Target reflect.Value, Major byte, Argument uint64) error {
+	isBytes := Target.Type().Elem().Kind() == reflect.Uint8
+	if isBytes && Major == cborBytes {
+		data, readErr := d.readLength(Argument)
+		if readErr != nil {
+			return readErr
+		}
+		if Target.Kind() == reflect.Array {
+			if len(data) != Target.Len() {
+				return fmt.Errorf("cannot decode %d bytes into '%s'", len(data), Target.Type())
+			}
+			reflect.Copy(Target, reflect.ValueOf(data))
+			return nil
+		}
+		Target.SetBytes(append([]byte(nil), data...))
+		return nil
+	}
+	if Major != cborArray {
+		return typeError("non array", Target.Interface())
+	}
+	length, lengthErr := d.length(Argument)
+	if lengthErr != nil {
+		return lengthErr
+	}
+	if Target.Kind() == reflect.Array {
+		if length != Target.Len() {
+			return fmt.Errorf("cannot decode %d items into '%s'", length, Target.Type())
+		}
+	} else {
+		Target.Set(reflect.MakeSlice(Target.Type(), length, length))
+	}
+	for i := 0; i < length; i++ {
+		if decodeErr := d.decode(Target.Index(i)); decodeErr != nil {
+			return decodeErr
+		}
+	}
+	return nil
+}
+
+func (d *cborDecoder) decodeMap(Target reflect.Value, Argument uint64) error {
+	length, lengthErr := d.length(Argument)
+	if lengthErr != nil {
+		return lengthErr
+	}
+	Target.Set(reflect.MakeMapWithSize(Target.Type(), length))
+	for i := 0; i < length; i++ {
+		key := reflect.New(Target.Type().Key()).Elem()
+		if decodeErr := d.decode(key); decodeErr != nil {
+			return decodeErr
+		}
+		if !hashable(key) {
+			return fmt.Errorf("unsupported map key '%v'", key)
+		}
+		value := reflect.New(Target.Type().Elem()).Elem()
+		if decodeErr := d.decode(value); decodeErr != nil {
+			return decodeErr
+		}
+		Target.SetMapIndex(key, value)
+	}
+	return nil
+}
+
+// Returns true if the value can be used as map key, interfaces must neither be nil nor hold a value that is not
+// comparable, like a slice or map decoded into an interface key.
+func hashable(Value reflect.Value) bool {
+	switch Value.Kind() {
+	case reflect.Interface:
+		return !Value.IsNil() && hashable(Value.Elem())
+	case reflect.Array:
+		for i := 0; i < Value.Len(); i++ {
+			if !hashable(Value.Index(i)) {
+				return false
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < Value.NumField(); i++ {
+			if !hashable(Value.Field(i)) {
+				return false
+			}
+		}
+	}
+	return Value.Type().Comparable()
+}
+
+// Decodes a map into the fields of a struct, unknown keys are skipped.
+func (d *cborDecoder) decodeStruct(Target reflect.Value, Argument uint64) error {
+	length, lengthErr := d.length(Argument)
+	if lengthErr != nil {
+		return lengthErr
+	}
+	fields := make(map[string]int)
+	for _, field := range cborFields(Target.Type()) {
+		fields[field.name] = field.index
+	}
+	for i := 0; i < length; i++ {
+		var name string
+		if decodeErr := d.decode(reflect.ValueOf(&name).Elem()); decodeErr != nil {
+			return decodeErr
+		}
+		index, known := fields[name]
+		if !known {
+			if _, skipErr := d.decodeAny(); skipErr != nil {
+				return skipErr
+			}
+			continue
+		}
+		if decodeErr := d.decode(Target.Field(index)); decodeErr != nil {
+			return decodeErr
+		}
+	}
+	return nil
+}
+
+// Decodes the next item into the natural Go type: uint64, int64, float64, bool, string, []byte, []any,
+// map[string]any if all keys are text or map[any]any, time.Time or nil.
+func (d *cborDecoder) decodeAny() (any, error) {
+	if depthErr := d.enter(); depthErr != nil {
+		return nil, depthErr
+	}
+	defer d.leave()
+
+	major, additional, argument, headerErr := d.header()
+	if headerErr != nil {
+		return nil, headerErr
+	}
+
+	switch major {
+	case cborUnsigned:
+		return argument, nil
+	case cborNegative:
+		return signed(major, argument)
+	case cborBytes:
+		data, readErr := d.readLength(argument)
+		return append([]byte(nil), data...), readErr
+	case cborText:
+		text, readErr := d.readLength(argument)
+		return string(text), readErr
+	case cborArray:
+		length, lengthErr := d.length(argument)
+		if lengthErr != nil {
+			return nil, lengthErr
+		}
+		items := make([]any, length)
+		for i := range items {
+			item, decodeErr := d.decodeAny()
+			if decodeErr != nil {
+				return nil, decodeErr
+			}
+			items[i] = item
+		}
+		return items, nil
+	case cborMap:
+		return d.decodeAnyMap(argument)
+	case cborTag:
+		content, decodeErr := d.decodeAny()
+		if decodeErr != nil {
+			return nil, decodeErr
+		}
+		text, isText := content.(string)
+		if argument != cborTimeTag || !isText {
+			return nil, fmt.Errorf("unsupported tag '%d'", argument)
+		}
+		return time.Parse(time.RFC3339Nano, text)
+	default:
+		switch {
+		case additional >= 25 && additional <= 27:
+			return float(major, additional, argument)
+		case argument == cborFalse&0x1f:
+			return false, nil
+		case argument == cborTrue&0x1f:
+			return true, nil
+		case argument == cborNull&0x1f:
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unsupported simple value '%d'", argument)
+	}
+}
+
+func (d *cborDecoder) decodeAnyMap(Argument uint64) (any, error) {
+	length, lengthErr := d.length(Argument)
+	if lengthErr != nil {
+		return nil, lengthErr
+	}
+	entries := make(map[any]any, length)
+	textKeys := true
+	for i := 0; i < length; i++ {
+		key, decodeErr := d.decodeAny()
+		if decodeErr != nil {
+			return nil, decodeErr
+		}
+		value, decodeErr := d.decodeAny()
+		if decodeErr != nil {
+			return nil, decodeErr
+		}
+		if key == nil || !reflect.TypeOf(key).Comparable() {
+			return nil, fmt.Errorf("unsupported map key '%T'", key)
+		}
+		_, isText := key.(string)
+		textKeys = textKeys && isText
+		entries[key] = value
+	}
+	if !textKeys {
+		return entries, nil
+	}
+	textEntries := make(map[string]any, length)
+	for key, value := range entries {
+		textEntries[key.(string)] = value
+	}
+	return textEntries, nil
+}
+
+func signed(Major byte, Argument uint64) (int64, error) {
+	if Argument > math.MaxInt64 {
+		return 0, fmt.Errorf("number overflows int64")
+	}
+	switch Major {
+	case cborUnsigned:
+		return int64(Argument), nil
+	case cborNegative:
+		return -1 - int64(Argument), nil
+	}
+	return 0, fmt.Errorf("cannot decode major type '%d' into a number", Major)
+}
+
+func float(Major byte, Additional byte, Argument uint64) (float64, error) {
+	switch {
+	case Major == cborUnsigned || Major == cborNegative:
+		number, numberErr := signed(Major, Argument)
+		return float64(number), numberErr
+	case Major != cborSimple:
+		return 0, fmt.Errorf("cannot decode major type '%d' into a float", Major)
+	case Additional == cborFloat16&0x1f:
+		return halfToFloat(uint16(Argument)), nil
+	case Additional == cborFloat32&0x1f:
+		return float64(math.Float32frombits(uint32(Argument))), nil
+	case Additional == cborFloat64&0x1f:
+		return math.Float64frombits(Argument), nil
+	}
+	return 0, fmt.Errorf("cannot decode simple value '%d' into a float", Argument)
+}
+
+func halfToFloat(Half uint16) float64 {
+	exponent, mantissa := int(Half>>10)&0x1f, float64(Half&0x3ff)
+	var value float64
+	switch exponent {
+	case 0:
+		value = math.Ldexp(mantissa, -24)
+	case 0x1f:
+		value = math.Inf(1)
+		if mantissa != 0 {
+			value = math.NaN()
+		}
+	default:
+		value = math.Ldexp(mantissa+1024, exponent-25)
+	}
+	if Half&0x8000 != 0 {
+		return -value
+	}
+	return value
+}
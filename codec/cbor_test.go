@@ -0,0 +1,210 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCBOR_MarshalVectors(t *testing.T) {
+	vectors := []struct {
+		value    any
+		expected string
+	}{
+		{0, "00"},
+		{23, "17"},
+		{24, "1818"},
+		{1000, "1903e8"},
+		{uint64(1000000000000), "1b000000e8d4a51000"},
+		{-1, "20"},
+		{-1000, "3903e7"},
+		{1.1, "fb3ff199999999999a"},
+		{float32(100000), "fa47c35000"},
+		{false, "f4"},
+		{true, "f5"},
+		{nil, "f6"},
+		{"", "60"},
+		{"IETF", "6449455446"},
+		{[]byte{1, 2, 3, 4}, "4401020304"},
+		{[]int{1, 2, 3}, "83010203"},
+		{[]any{1, []int{2, 3}}, "8201820203"},
+		{map[string]int{"b": 2, "a": 1}, "a2616101616202"},
+		{time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC), "c074323031332d30332d32315432303a30343a30305a"},
+	}
+	for _, vector := range vectors {
+		data, err := CBOR.Marshal(vector.value)
+		if err != nil {
+			t.Errorf("Unexpected error occured: %s", err.Error())
+			continue
+		}
+		if value := hex.EncodeToString(data); value != vector.expected {
+			t.Errorf("Expected Marshal(%v) To Equal '%s' Actual '%s'", vector.value, vector.expected, value)
+		}
+	}
+}
+
+func TestCBOR_UnmarshalAny(t *testing.T) {
+	vectors := []struct {
+		data     string
+		expected any
+	}{
+		{"1903e8", uint64(1000)},
+		{"3903e7", int64(-1000)},
+		{"f93c00", 1.0},
+		{"f9c400", -4.0},
+		{"f97c00", math.Inf(1)},
+		{"fa47c35000", 100000.0},
+		{"f5", true},
+		{"f6", nil},
+		{"6449455446", "IETF"},
+		{"8201820203", []any{uint64(1), []any{uint64(2), uint64(3)}}},
+		{"a201020304", map[any]any{uint64(1): uint64(2), uint64(3): uint64(4)}},
+		{"a26161016162820203", map[string]any{"a": uint64(1), "b": []any{uint64(2), uint64(3)}}},
+	}
+	for _, vector := range vectors {
+		data, _ := hex.DecodeString(vector.data)
+		var value any
+		if err := CBOR.Unmarshal(data, &value); err != nil {
+			t.Errorf("Unexpected error occured: %s", err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(value, vector.expected) {
+			t.Errorf("Expected Unmarshal(%s) To Equal '%#v' Actual '%#v'", vector.data, vector.expected, value)
+		}
+	}
+}
+
+type Order struct {
+	ID       string            `cbor:"id"`
+	Amount   int64             `cbor:"amount"`
+	Price    float64           `cbor:"price"`
+	Paid     bool              `cbor:"paid"`
+	Items    []string          `cbor:"items"`
+	Tags     map[string]string `cbor:"tags"`
+	Note     *string           `cbor:"note"`
+	Created  time.Time         `cbor:"created"`
+	Checksum [4]byte           `cbor:"checksum"`
+	Secret   string            `cbor:"-"`
+	internal int
+}
+
+func TestCBOR_RoundTrip(t *testing.T) {
+	note := "fragile"
+	order := Order{
+		ID:       "o-1",
+		Amount:   -3,
+		Price:    9.99,
+		Paid:     true,
+		Items:    []string{"a", "b"},
+		Tags:     map[string]string{"channel": "web"},
+		Note:     &note,
+		Created:  time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		Checksum: [4]byte{1, 2, 3, 4},
+		Secret:   "hidden",
+		internal: 1,
+	}
+
+	data, err := CBOR.Marshal(order)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	var decoded Order
+	if err := CBOR.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	order.Secret, order.internal = "", 0
+	if !reflect.DeepEqual(decoded, order) {
+		t.Errorf("Expected Decoded To Equal '%+v' Actual '%+v'", order, decoded)
+	}
+
+	var generic map[string]any
+	if err := CBOR.Unmarshal(data, &generic); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if _, exists := generic["Secret"]; exists {
+		t.Errorf("Expected Field Secret To Be Skipped")
+	}
+	if value := generic["amount"]; value != int64(-3) {
+		t.Errorf("Expected amount To Equal '%d' Actual '%v'", -3, value)
+	}
+}
+
+func TestCBOR_UnmarshalErrors(t *testing.T) {
+	var small int8
+	var text string
+	var number int
+	var generic any
+	var items []any
+	var genericMap map[any]any
+	var countMap map[any]int
+	invalid := []struct {
+		data   string
+		target any
+	}{
+		{"1903e8", &small},
+		{"01", &text},
+		{"6449", &text},
+		{"0101", &number},
+		{"1b", &number},
+		{"ff", &number},
+		{"a1f600", &generic},
+		{"a18000", &generic},
+		{"a1a00000", &generic},
+		{"a1810101", &genericMap},
+		{"a1a1010101", &countMap},
+		{"a1f601", &countMap},
+	}
+	for _, vector := range invalid {
+		data, _ := hex.DecodeString(vector.data)
+		if err := CBOR.Unmarshal(data, vector.target); err == nil {
+			t.Errorf("Expected Error For Data '%s' Into '%T'", vector.data, vector.target)
+		}
+	}
+	nested := bytes.Repeat([]byte{0x81}, 5<<20)
+	for _, target := range []any{&generic, &items} {
+		if err := CBOR.Unmarshal(nested, target); err == nil {
+			t.Errorf("Expected Error For Deeply Nested Data Into '%T'", target)
+		}
+	}
+	if err := CBOR.Unmarshal(append(bytes.Repeat([]byte{0x81}, cborMaxDepth/2), 0x01), &generic); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+
+	if err := CBOR.Unmarshal([]byte{0x01}, number); err == nil {
+		t.Errorf("Expected Error For Non Pointer Target")
+	}
+	if _, err := CBOR.Marshal(make(chan int)); err == nil {
+		t.Errorf("Expected Error For Unsupported Type")
+	}
+}
+
+func FuzzCBOR_Unmarshal(f *testing.F) {
+	seeds := []string{"00", "a1f600", "a18000", "8181818101", "a26161016162820203", "c074323031332d30332d32315432303a30343a30305a", "f93c00", "a1810101", "a1a1010101"}
+	for _, seed := range seeds {
+		data, _ := hex.DecodeString(seed)
+		f.Add(data)
+	}
+	event, _ := CBOR.Marshal(encodedEvent{ID: "1", Headers: map[string]string{"a": "b"}, Data: []byte{1}})
+	f.Add(event)
+
+	f.Fuzz(func(t *testing.T, Data []byte) {
+		var generic any
+		if err := CBOR.Unmarshal(Data, &generic); err == nil {
+			if _, err := CBOR.Marshal(generic); err != nil {
+				t.Errorf("Unexpected error occured: %s", err.Error())
+			}
+		}
+		var decoded encodedEvent
+		_ = CBOR.Unmarshal(Data, &decoded)
+		var envelope Envelope
+		_ = CBOR.Unmarshal(Data, &envelope)
+		var genericMap map[any]any
+		_ = CBOR.Unmarshal(Data, &genericMap)
+		var countMap map[any]int
+		_ = CBOR.Unmarshal(Data, &countMap)
+	})
+}
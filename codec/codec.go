@@ -0,0 +1,105 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+
+	err "github.com/hijgo/go-bloc/error"
+)
+
+// Encodes values to bytes and decodes them back, for example to persist or transmit events and states.
+type Codec interface {
+	// Returns the name of the codec, for example "json".
+	Name() string
+	// Encodes the value.
+	//
+	// Value : The value that should be encoded
+	Marshal(Value any) ([]byte, error)
+	// Decodes the data into the value.
+	//
+	// Data : The encoded data
+	//
+	// Value : Pointer to the value the data should be decoded into
+	Unmarshal(Data []byte, Value any) error
+}
+
+// Codec encoding values as JSON, using encoding/json.
+var JSON Codec = jsonCodec{}
+
+// Codec encoding values with encoding/gob. Values stored in interfaces have to be registered with gob.Register.
+var Gob Codec = gobCodec{}
+
+// Codec encoding values as compact binary CBOR (RFC 8949).
+// Supports booleans, numbers, strings, byte slices, slices, arrays, maps, structs, pointers and time.Time.
+// Structs are encoded as maps keyed by field name, which can be changed with a `cbor:"name"` tag, or skipped with
+// `cbor:"-"`.
+var CBOR Codec = cborCodec{}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string {
+	return "json"
+}
+
+func (jsonCodec) Marshal(Value any) ([]byte, error) {
+	data, encodeErr := json.Marshal(Value)
+	if encodeErr != nil {
+		return nil, &err.Error{Context: "Cannot encode JSON!", Err: encodeErr}
+	}
+	return data, nil
+}
+
+func (jsonCodec) Unmarshal(Data []byte, Value any) error {
+	if decodeErr := json.Unmarshal(Data, Value); decodeErr != nil {
+		return &err.Error{Context: "Cannot decode JSON!", Err: decodeErr}
+	}
+	return nil
+}
+
+type gobCodec struct{}
+
+func (gobCodec) Name() string {
+	return "gob"
+}
+
+func (gobCodec) Marshal(Value any) ([]byte, error) {
+	var buffer bytes.Buffer
+	if encodeErr := gob.NewEncoder(&buffer).Encode(Value); encodeErr != nil {
+		return nil, &err.Error{Context: "Cannot encode gob!", Err: encodeErr}
+	}
+	return buffer.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(Data []byte, Value any) error {
+	if decodeErr := gob.NewDecoder(bytes.NewReader(Data)).Decode(Value); decodeErr != nil {
+		return &err.Error{Context: "Cannot decode gob!", Err: decodeErr}
+	}
+	return nil
+}
+
+type cborCodec struct{}
+
+func (cborCodec) Name() string {
+	return "cbor"
+}
+
+func (cborCodec) Marshal(Value any) ([]byte, error) {
+	var buffer bytes.Buffer
+	if encodeErr := encodeCBOR(&buffer, Value); encodeErr != nil {
+		return nil, &err.Error{Context: "Cannot encode CBOR!", Err: encodeErr}
+	}
+	return buffer.Bytes(), nil
+}
+
+func (cborCodec) Unmarshal(Data []byte, Value any) error {
+	if decodeErr := decodeCBOR(Data, Value); decodeErr != nil {
+		return &err.Error{Context: "Cannot decode CBOR!", Err: decodeErr}
+	}
+	return nil
+}
+
+func typeError(Expected string, Actual any) error {
+	return fmt.Errorf("cannot decode %s into '%T'", Expected, Actual)
+}
@@ -0,0 +1,321 @@
+package codec
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	err "github.com/hijgo/go-bloc/error"
+	"github.com/hijgo/go-bloc/event"
+)
+
+// Maps concrete types to names, so values stored in an interface, like a sealed event type used as E, can be encoded
+// with a discriminator and decoded back into the right concrete type.
+type Registry struct {
+	lock      sync.RWMutex
+	types     map[string]reflect.Type
+	names     map[reflect.Type]string
+	versions  map[string]uint
+	upcasters map[upcastKey]upcaster
+}
+
+// The encoded form of a value whose concrete type is registered.
+//
+// Type : The name the concrete type was registered with
+//
+// Version : The schema version of the encoded value, 0 for values encoded before versioning which are treated as
+// version 1
+//
+// Data : The encoded value
+type Envelope struct {
+	Type    string `json:"type" cbor:"type"`
+	Version uint   `json:"version,omitempty" cbor:"version"`
+	Data    []byte `json:"data" cbor:"data"`
+}
+
+type encodedEvent struct {
+	TimeStamp     int64             `json:"timeStamp" cbor:"timeStamp"`
+	Time          time.Time         `json:"time" cbor:"time"`
+	Sequence      uint64            `json:"sequence" cbor:"sequence"`
+	ID            string            `json:"id" cbor:"id"`
+	CorrelationID string            `json:"correlationId" cbor:"correlationId"`
+	CausationID   string            `json:"causationId,omitempty" cbor:"causationId"`
+	Source        string            `json:"source,omitempty" cbor:"source"`
+	Headers       map[string]string `json:"headers,omitempty" cbor:"headers"`
+	Type          string            `json:"type,omitempty" cbor:"type"`
+	Version       uint              `json:"version,omitempty" cbor:"version"`
+	Priority      int               `json:"priority,omitempty" cbor:"priority"`
+	Deadline      time.Time         `json:"deadline" cbor:"deadline"`
+	TTL           time.Duration     `json:"ttl,omitempty" cbor:"ttl"`
+	Data          []byte            `json:"data" cbor:"data"`
+}
+
+// Function that should be called if a new Registry is needed.
+func CreateRegistry() *Registry {
+	return &Registry{
+		types:     make(map[string]reflect.Type),
+		names:     make(map[reflect.Type]string),
+		versions:  make(map[string]uint),
+		upcasters: make(map[upcastKey]upcaster),
+	}
+}
+
+// Registers the concrete type T under the given name.
+//
+// T : The concrete type, must not be an interface
+//
+// Types : The registry the type should be registered with
+//
+// Name : The discriminator written for values of type T, must be unique within the registry
+//
+// Will return an error if T is an interface or the name or type is already registered.
+func Register[T any](Types *Registry, Name string) error {
+	valueType := reflect.TypeOf((*T)(nil)).Elem()
+	if valueType.Kind() == reflect.Interface {
+		return &err.Error{
+			Context: "Cannot register type, only concrete types can be registered!",
+			Err:     fmt.Errorf("type '%s' is an interface", valueType),
+		}
+	}
+
+	Types.lock.Lock()
+	defer Types.lock.Unlock()
+	if existing, exists := Types.types[Name]; exists {
+		return &err.Error{
+			Context: "Cannot register type, name already registered!",
+			Err:     fmt.Errorf("name '%s' already registered for type '%s'", Name, existing),
+		}
+	}
+	if existing, exists := Types.names[valueType]; exists {
+		return &err.Error{
+			Context: "Cannot register type, type already registered!",
+			Err:     fmt.Errorf("type '%s' already registered as '%s'", valueType, existing),
+		}
+	}
+	Types.types[Name] = valueType
+	Types.names[valueType] = Name
+	return nil
+}
+
+// Returns the name the concrete type of the value was registered with. If the type is not registered, will return
+// false as second value.
+//
+// Value : The value whose type should be looked up
+func (r *Registry) GetName(Value any) (string, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	name, exists := r.names[reflect.TypeOf(Value)]
+	return name, exists
+}
+
+func (r *Registry) getType(Name string) (reflect.Type, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	valueType, exists := r.types[Name]
+	return valueType, exists
+}
+
+// Encodes the value together with the name of its concrete type.
+//
+// Codec : The codec used to encode the value and the envelope
+//
+// Value : The value that should be encoded, its concrete type must be registered
+//
+// Will return an error if the type of the value is not registered or the value cannot be encoded.
+func (r *Registry) Encode(Codec Codec, Value any) ([]byte, error) {
+	envelope, encodeErr := r.Wrap(Codec, Value)
+	if encodeErr != nil {
+		return nil, encodeErr
+	}
+	return Codec.Marshal(envelope)
+}
+
+// Encodes the value and returns it together with the name and version of its concrete type, without encoding the
+// Envelope itself.
+//
+// Codec : The codec used to encode the value
+//
+// Value : The value that should be encoded, its concrete type must be registered
+//
+// Will return an error if the type of the value is not registered or the value cannot be encoded.
+func (r *Registry) Wrap(Codec Codec, Value any) (Envelope, error) {
+	name, exists := r.GetName(Value)
+	if !exists {
+		return Envelope{}, &err.Error{
+			Context: "Cannot encode value, type is not registered!",
+			Err:     fmt.Errorf("type '%T' not registered", Value),
+		}
+	}
+	data, encodeErr := Codec.Marshal(Value)
+	if encodeErr != nil {
+		return Envelope{}, encodeErr
+	}
+	return Envelope{Type: name, Version: r.GetVersion(name), Data: data}, nil
+}
+
+// Decodes a value encoded by Registry.Encode into its concrete type and returns it as T.
+//
+// T : The type the value should be returned as, usually the interface implemented by all registered types
+//
+// Types : The registry the concrete type was registered with
+//
+// Codec : The codec the value was encoded with
+//
+// Data : The encoded value
+//
+// Will return an error if the type is unknown, the data cannot be decoded or the concrete type does not implement T.
+func Decode[T any](Types *Registry, Codec Codec, Data []byte) (T, error) {
+	var envelope Envelope
+	if decodeErr := Codec.Unmarshal(Data, &envelope); decodeErr != nil {
+		var empty T
+		return empty, decodeErr
+	}
+	return Unwrap[T](Types, Codec, envelope)
+}
+
+// Decodes the data of the Envelope into the concrete type named by the Envelope and returns it as T. Data of an older
+// version is decoded into the type it was written with and upcast to the latest version, see RegisterUpcaster.
+//
+// T : The type the value should be returned as, usually the interface implemented by all registered types
+//
+// Types : The registry the concrete type was registered with
+//
+// Codec : The codec the data of the Envelope was encoded with
+//
+// Wrapped : The Envelope holding the name of the type and the encoded value
+//
+// Will return an error if the type is unknown, the data cannot be decoded or upcast or the concrete type does not
+// implement T.
+func Unwrap[T any](Types *Registry, Codec Codec, Wrapped Envelope) (T, error) {
+	var empty T
+	valueType, exists := Types.getType(Wrapped.Type)
+	if !exists {
+		return empty, &err.Error{
+			Context: "Cannot decode value, type is not registered!",
+			Err:     fmt.Errorf("type '%s' not registered", Wrapped.Type),
+		}
+	}
+
+	var decoded any
+	version, latest := Wrapped.Version, Types.GetVersion(Wrapped.Type)
+	if version == 0 {
+		version = 1
+	}
+	switch {
+	case version > latest:
+		return empty, &err.Error{
+			Context: "Cannot decode value, version is newer than the latest known version!",
+			Err:     fmt.Errorf("version %d of type '%s' is newer than version %d", version, Wrapped.Type, latest),
+		}
+	case version < latest:
+		upcast, upcastErr := Types.upcast(Codec, Wrapped.Type, version, latest, Wrapped.Data)
+		if upcastErr != nil {
+			return empty, upcastErr
+		}
+		decoded = upcast
+	default:
+		value := reflect.New(valueType)
+		if decodeErr := Codec.Unmarshal(Wrapped.Data, value.Interface()); decodeErr != nil {
+			return empty, decodeErr
+		}
+		decoded = value.Elem().Interface()
+	}
+
+	result, implements := decoded.(T)
+	if !implements {
+		return empty, &err.Error{
+			Context: "Cannot decode value, type does not match!",
+			Err:     fmt.Errorf("type '%s' is not '%T'", valueType, (*T)(nil)),
+		}
+	}
+	return result, nil
+}
+
+// Encodes the event including its metadata.
+//
+// T : The type of data carried with by the event
+//
+// Codec : The codec used to encode the event
+//
+// Types : Registry used to encode the data with its concrete type and version, nil if T is not an interface. If set,
+// the version of the registered type is written instead of the version of the event
+//
+// Event : The event that should be encoded
+func EncodeEvent[T any](Codec Codec, Types *Registry, Event event.Event[T]) ([]byte, error) {
+	encoded := encodedEvent{
+		TimeStamp:     Event.TimeStamp,
+		Time:          Event.Time,
+		Sequence:      Event.Sequence,
+		ID:            Event.ID,
+		CorrelationID: Event.CorrelationID,
+		CausationID:   Event.CausationID,
+		Source:        Event.Source,
+		Headers:       Event.Headers.ToMap(),
+		Version:       Event.Version,
+		Priority:      Event.Priority,
+		Deadline:      Event.Deadline,
+		TTL:           Event.TTL,
+	}
+	if Types != nil {
+		envelope, encodeErr := Types.Wrap(Codec, Event.Data)
+		if encodeErr != nil {
+			return nil, encodeErr
+		}
+		encoded.Type, encoded.Version, encoded.Data = envelope.Type, envelope.Version, envelope.Data
+	} else {
+		data, encodeErr := Codec.Marshal(Event.Data)
+		if encodeErr != nil {
+			return nil, encodeErr
+		}
+		encoded.Data = data
+	}
+	return Codec.Marshal(encoded)
+}
+
+// Decodes an event encoded by EncodeEvent. If a Registry is given, data of an older version is upcast to the latest
+// version, which is then set as version of the event.
+//
+// T : The type of data carried with by the event
+//
+// Codec : The codec the event was encoded with
+//
+// Types : Registry used to decode the data into its concrete type, nil if T is not an interface
+//
+// Data : The encoded event
+func DecodeEvent[T any](Codec Codec, Types *Registry, Data []byte) (event.Event[T], error) {
+	var encoded encodedEvent
+	if decodeErr := Codec.Unmarshal(Data, &encoded); decodeErr != nil {
+		return event.Event[T]{}, decodeErr
+	}
+
+	decoded := event.Event[T]{
+		TimeStamp: encoded.TimeStamp,
+		Time:      encoded.Time,
+		Sequence:  encoded.Sequence,
+		Metadata: event.Metadata{
+			ID:            encoded.ID,
+			CorrelationID: encoded.CorrelationID,
+			CausationID:   encoded.CausationID,
+			Source:        encoded.Source,
+			Headers:       event.CreateHeaders(encoded.Headers),
+			Version:       encoded.Version,
+			Priority:      encoded.Priority,
+			Deadline:      encoded.Deadline,
+			TTL:           encoded.TTL,
+		},
+	}
+	if Types != nil {
+		wrapped := Envelope{Type: encoded.Type, Version: encoded.Version, Data: encoded.Data}
+		data, decodeErr := Unwrap[T](Types, Codec, wrapped)
+		if decodeErr != nil {
+			return event.Event[T]{}, decodeErr
+		}
+		decoded.Data, decoded.Version = data, Types.GetVersion(encoded.Type)
+		return decoded, nil
+	}
+	if decodeErr := Codec.Unmarshal(encoded.Data, &decoded.Data); decodeErr != nil {
+		return event.Event[T]{}, decodeErr
+	}
+	return decoded, nil
+}
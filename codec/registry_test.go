@@ -0,0 +1,124 @@
+package codec
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/event"
+)
+
+type CartEvent interface {
+	isCartEvent()
+}
+
+type ItemAdded struct {
+	Item     string `json:"item" cbor:"item"`
+	Quantity int    `json:"quantity" cbor:"quantity"`
+}
+
+type CartCleared struct {
+	Reason string `json:"reason" cbor:"reason"`
+}
+
+func (ItemAdded) isCartEvent() {}
+
+func (CartCleared) isCartEvent() {}
+
+func createCartRegistry(t *testing.T) *Registry {
+	types := CreateRegistry()
+	if err := Register[ItemAdded](types, "item_added"); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := Register[CartCleared](types, "cart_cleared"); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	return types
+}
+
+func TestRegister(t *testing.T) {
+	types := createCartRegistry(t)
+
+	if err := Register[ItemAdded](types, "other"); err == nil {
+		t.Errorf("Expected Error When Registering Type Twice")
+	}
+	if err := Register[string](types, "item_added"); err == nil {
+		t.Errorf("Expected Error When Registering Name Twice")
+	}
+	if err := Register[CartEvent](types, "cart_event"); err == nil {
+		t.Errorf("Expected Error When Registering Interface")
+	}
+	if name, exists := types.GetName(CartCleared{}); !exists || name != "cart_cleared" {
+		t.Errorf("Expected GetName To Return '%s' Actual '%s'", "cart_cleared", name)
+	}
+}
+
+func TestRegistry_EncodeDecode(t *testing.T) {
+	types := createCartRegistry(t)
+	for _, codec := range []Codec{JSON, Gob, CBOR} {
+		var value CartEvent = ItemAdded{Item: "apple", Quantity: 3}
+		data, err := types.Encode(codec, value)
+		if err != nil {
+			t.Fatalf("Unexpected error occured: %s", err.Error())
+		}
+		decoded, err := Decode[CartEvent](types, codec, data)
+		if err != nil {
+			t.Fatalf("Unexpected error occured: %s", err.Error())
+		}
+		if !reflect.DeepEqual(decoded, value) {
+			t.Errorf("Expected Decoded '%s' Value To Equal '%+v' Actual '%+v'", codec.Name(), value, decoded)
+		}
+
+		if _, err := types.Encode(codec, struct{}{}); err == nil {
+			t.Errorf("Expected Error When Encoding Unregistered Type With '%s'", codec.Name())
+		}
+		unknown, _ := codec.Marshal(Envelope{Type: "unknown"})
+		if _, err := Decode[CartEvent](types, codec, unknown); err == nil {
+			t.Errorf("Expected Error When Decoding Unregistered Type With '%s'", codec.Name())
+		}
+	}
+
+	if err := Register[int](types, "number"); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	data, _ := types.Encode(JSON, 1)
+	if _, err := Decode[CartEvent](types, JSON, data); err == nil {
+		t.Errorf("Expected Error When Decoding Type Not Implementing Interface")
+	}
+}
+
+func TestEncodeEvent(t *testing.T) {
+	types := createCartRegistry(t)
+
+	original := event.CreateEventAt[CartEvent](CartCleared{Reason: "logout"}, time.Date(2024, 5, 6, 7, 8, 9, 10, time.UTC),
+		event.WithSource("auth"), event.WithHeader("tenant", "a"), event.WithVersion(1),
+		event.WithPriority(2), event.WithTTL(time.Minute), event.WithDeadline(time.Date(2024, 5, 6, 8, 0, 0, 0, time.UTC))).WithSequence(4)
+	for _, codec := range []Codec{JSON, Gob, CBOR} {
+		data, err := EncodeEvent(codec, types, original)
+		if err != nil {
+			t.Fatalf("Unexpected error occured: %s", err.Error())
+		}
+		decoded, err := DecodeEvent[CartEvent](codec, types, data)
+		if err != nil {
+			t.Fatalf("Unexpected error occured: %s", err.Error())
+		}
+		if !reflect.DeepEqual(decoded, original) {
+			t.Errorf("Expected Decoded '%s' Event To Equal '%+v' Actual '%+v'", codec.Name(), original, decoded)
+		}
+	}
+
+	plain := event.CreateEvent(ItemAdded{Item: "pear", Quantity: 1})
+	for _, codec := range []Codec{JSON, Gob, CBOR} {
+		data, err := EncodeEvent(codec, nil, plain)
+		if err != nil {
+			t.Fatalf("Unexpected error occured: %s", err.Error())
+		}
+		decoded, err := DecodeEvent[ItemAdded](codec, nil, data)
+		if err != nil {
+			t.Fatalf("Unexpected error occured: %s", err.Error())
+		}
+		if decoded.Data != plain.Data || decoded.ID != plain.ID || !decoded.Time.Equal(plain.Time) {
+			t.Errorf("Expected Decoded '%s' Event To Equal '%+v' Actual '%+v'", codec.Name(), plain, decoded)
+		}
+	}
+}
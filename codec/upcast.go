@@ -0,0 +1,168 @@
+package codec
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	err "github.com/hijgo/go-bloc/error"
+)
+
+type upcastKey struct {
+	name    string
+	version uint
+}
+
+type upcaster struct {
+	from   reflect.Type
+	to     reflect.Type
+	upcast func(Value any) (any, error)
+}
+
+// Registers a function that transforms data of the type registered under the given name from one version into the
+// next version. Types are versioned starting at 1, the latest version of a type is one above the highest version an
+// upcaster is registered for, and the type registered with Register always describes the latest version. Old
+// versions are kept as separate types, which are only used to decode data written with that version.
+//
+// From : The type data of the given version is decoded into
+//
+// To : The type of the next version, the registered type for the last upcaster of a chain
+//
+// Types : The registry the type is registered with
+//
+// Name : The name the type is registered with
+//
+// Version : The version the upcaster transforms from
+//
+// Upcast : Function transforming a value of version Version into a value of version Version + 1
+//
+// Will return an error if the version is 0 or an upcaster for the version is already registered.
+func RegisterUpcaster[From any, To any](Types *Registry, Name string, Version uint, Upcast func(Value From) (To, error)) error {
+	if Version == 0 {
+		return &err.Error{
+			Context: "Cannot register upcaster, versions start at 1!",
+			Err:     fmt.Errorf("invalid version 0 for type '%s'", Name),
+		}
+	}
+
+	Types.lock.Lock()
+	defer Types.lock.Unlock()
+	key := upcastKey{name: Name, version: Version}
+	if _, exists := Types.upcasters[key]; exists {
+		return &err.Error{
+			Context: "Cannot register upcaster, version already registered!",
+			Err:     fmt.Errorf("upcaster from version %d of type '%s' already registered", Version, Name),
+		}
+	}
+	Types.upcasters[key] = upcaster{
+		from: reflect.TypeOf((*From)(nil)).Elem(),
+		to:   reflect.TypeOf((*To)(nil)).Elem(),
+		upcast: func(Value any) (any, error) {
+			return Upcast(Value.(From))
+		},
+	}
+	if Version+1 > Types.versions[Name] {
+		Types.versions[Name] = Version + 1
+	}
+	return nil
+}
+
+// Returns the latest version of the type registered under the given name, 1 if no upcaster is registered for it.
+//
+// Name : The name the type is registered with
+func (r *Registry) GetVersion(Name string) uint {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if version, exists := r.versions[Name]; exists {
+		return version
+	}
+	return 1
+}
+
+// Checks that data of every version of every registered type can be upcast to the latest version. Meant to be called
+// from a test, so a missing or mismatching upcaster is noticed before old data has to be decoded.
+//
+// Will return an error describing the first broken chain, ordered by the name of the type.
+func (r *Registry) VerifyUpcasters() error {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	names := make([]string, 0, len(r.versions))
+	for name := range r.versions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		latest := r.types[name]
+		if latest == nil {
+			return &err.Error{
+				Context: "Upcasters are invalid, type is not registered!",
+				Err:     fmt.Errorf("upcasters registered for unknown type '%s'", name),
+			}
+		}
+		for version := uint(1); version < r.versions[name]; version++ {
+			current, exists := r.upcasters[upcastKey{name: name, version: version}]
+			if !exists {
+				return &err.Error{
+					Context: "Upcasters are invalid, version has no path to the latest version!",
+					Err:     fmt.Errorf("no upcaster from version %d of type '%s'", version, name),
+				}
+			}
+			expected := latest
+			if next, exists := r.upcasters[upcastKey{name: name, version: version + 1}]; exists {
+				expected = next.from
+			}
+			if current.to != expected {
+				return &err.Error{
+					Context: "Upcasters are invalid, types of consecutive versions do not match!",
+					Err: fmt.Errorf("upcaster from version %d of type '%s' returns '%s' instead of '%s'",
+						version, name, current.to, expected),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Registry) upcast(Codec Codec, Name string, Version uint, Latest uint, Data []byte) (any, error) {
+	r.lock.RLock()
+	first, exists := r.upcasters[upcastKey{name: Name, version: Version}]
+	r.lock.RUnlock()
+	if !exists {
+		return nil, &err.Error{
+			Context: "Cannot decode value, no upcaster registered for version!",
+			Err:     fmt.Errorf("no upcaster from version %d of type '%s'", Version, Name),
+		}
+	}
+
+	value := reflect.New(first.from)
+	if decodeErr := Codec.Unmarshal(Data, value.Interface()); decodeErr != nil {
+		return nil, decodeErr
+	}
+	current := value.Elem().Interface()
+
+	for version := Version; version < Latest; version++ {
+		r.lock.RLock()
+		step, exists := r.upcasters[upcastKey{name: Name, version: version}]
+		r.lock.RUnlock()
+		if !exists {
+			return nil, &err.Error{
+				Context: "Cannot decode value, no upcaster registered for version!",
+				Err:     fmt.Errorf("no upcaster from version %d of type '%s'", version, Name),
+			}
+		}
+		if current == nil || !reflect.TypeOf(current).AssignableTo(step.from) {
+			return nil, &err.Error{
+				Context: "Cannot decode value, upcaster does not accept the previous version!",
+				Err:     fmt.Errorf("upcaster from version %d of type '%s' expects '%s' got '%T'", version, Name, step.from, current),
+			}
+		}
+		upcast, upcastErr := step.upcast(current)
+		if upcastErr != nil {
+			return nil, &err.Error{Context: "Cannot decode value, upcasting failed!", Err: upcastErr}
+		}
+		current = upcast
+	}
+	return current, nil
+}
@@ -0,0 +1,156 @@
+package codec
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/event"
+)
+
+type PriceChangedV1 struct {
+	Price int `json:"price" cbor:"price"`
+}
+
+type PriceChangedV2 struct {
+	Cents int `json:"cents" cbor:"cents"`
+}
+
+type PriceChanged struct {
+	Cents    int    `json:"cents" cbor:"cents"`
+	Currency string `json:"currency" cbor:"currency"`
+}
+
+func (PriceChanged) isCartEvent() {}
+
+func createVersionedRegistry(t *testing.T) *Registry {
+	types := createCartRegistry(t)
+	if err := Register[PriceChanged](types, "price_changed"); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := RegisterUpcaster(types, "price_changed", 1, func(Value PriceChangedV1) (PriceChangedV2, error) {
+		if Value.Price < 0 {
+			return PriceChangedV2{}, fmt.Errorf("negative price %d", Value.Price)
+		}
+		return PriceChangedV2{Cents: Value.Price * 100}, nil
+	}); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := RegisterUpcaster(types, "price_changed", 2, func(Value PriceChangedV2) (PriceChanged, error) {
+		return PriceChanged{Cents: Value.Cents, Currency: "EUR"}, nil
+	}); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	return types
+}
+
+func TestRegistry_VerifyUpcasters(t *testing.T) {
+	types := createVersionedRegistry(t)
+	if err := types.VerifyUpcasters(); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	if value := types.GetVersion("price_changed"); value != 3 {
+		t.Errorf("Expected GetVersion To Equal '%d' Actual '%d'", 3, value)
+	}
+	if value := types.GetVersion("item_added"); value != 1 {
+		t.Errorf("Expected GetVersion To Equal '%d' Actual '%d'", 1, value)
+	}
+
+	if err := RegisterUpcaster(types, "price_changed", 2, func(Value PriceChangedV2) (PriceChanged, error) {
+		return PriceChanged{}, nil
+	}); err == nil {
+		t.Errorf("Expected Error When Registering Upcaster Twice")
+	}
+	if err := RegisterUpcaster(types, "price_changed", 0, func(Value PriceChangedV1) (PriceChangedV1, error) {
+		return Value, nil
+	}); err == nil {
+		t.Errorf("Expected Error When Registering Version 0")
+	}
+
+	missing := createCartRegistry(t)
+	_ = RegisterUpcaster(missing, "item_added", 2, func(Value PriceChangedV2) (ItemAdded, error) { return ItemAdded{}, nil })
+	if err := missing.VerifyUpcasters(); err == nil {
+		t.Errorf("Expected Error For Missing Upcaster From Version 1")
+	}
+
+	mismatching := createCartRegistry(t)
+	_ = RegisterUpcaster(mismatching, "item_added", 1, func(Value PriceChangedV1) (PriceChangedV2, error) {
+		return PriceChangedV2{}, nil
+	})
+	if err := mismatching.VerifyUpcasters(); err == nil {
+		t.Errorf("Expected Error For Upcaster Not Returning The Registered Type")
+	}
+
+	unknown := CreateRegistry()
+	_ = RegisterUpcaster(unknown, "unknown", 1, func(Value PriceChangedV1) (PriceChanged, error) { return PriceChanged{}, nil })
+	if err := unknown.VerifyUpcasters(); err == nil {
+		t.Errorf("Expected Error For Upcaster Of Unregistered Type")
+	}
+}
+
+func TestUnwrap_Upcast(t *testing.T) {
+	types := createVersionedRegistry(t)
+	for _, codec := range []Codec{JSON, Gob, CBOR} {
+		v1, _ := codec.Marshal(PriceChangedV1{Price: 3})
+		v2, _ := codec.Marshal(PriceChangedV2{Cents: 250})
+		latest, _ := codec.Marshal(PriceChanged{Cents: 99, Currency: "USD"})
+
+		cases := []struct {
+			Envelope Envelope
+			Expected CartEvent
+		}{
+			{Envelope{Type: "price_changed", Data: v1}, PriceChanged{Cents: 300, Currency: "EUR"}},
+			{Envelope{Type: "price_changed", Version: 1, Data: v1}, PriceChanged{Cents: 300, Currency: "EUR"}},
+			{Envelope{Type: "price_changed", Version: 2, Data: v2}, PriceChanged{Cents: 250, Currency: "EUR"}},
+			{Envelope{Type: "price_changed", Version: 3, Data: latest}, PriceChanged{Cents: 99, Currency: "USD"}},
+		}
+		for _, c := range cases {
+			decoded, err := Unwrap[CartEvent](types, codec, c.Envelope)
+			if err != nil {
+				t.Fatalf("Unexpected error occured: %s", err.Error())
+			}
+			if !reflect.DeepEqual(decoded, c.Expected) {
+				t.Errorf("Expected Upcast '%s' Version '%d' To Equal '%+v' Actual '%+v'",
+					codec.Name(), c.Envelope.Version, c.Expected, decoded)
+			}
+		}
+
+		if _, err := Unwrap[CartEvent](types, codec, Envelope{Type: "price_changed", Version: 4, Data: latest}); err == nil {
+			t.Errorf("Expected Error When Decoding Newer Version With '%s'", codec.Name())
+		}
+		negative, _ := codec.Marshal(PriceChangedV1{Price: -1})
+		if _, err := Unwrap[CartEvent](types, codec, Envelope{Type: "price_changed", Version: 1, Data: negative}); err == nil {
+			t.Errorf("Expected Error When Upcaster Fails With '%s'", codec.Name())
+		}
+	}
+}
+
+func TestDecodeEvent_Upcast(t *testing.T) {
+	old := createCartRegistry(t)
+	if err := Register[PriceChangedV1](old, "price_changed"); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	current := createVersionedRegistry(t)
+
+	stored := event.CreateEventAt(PriceChangedV1{Price: 5}, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	for _, codec := range []Codec{JSON, Gob, CBOR} {
+		data, err := EncodeEvent(codec, old, stored)
+		if err != nil {
+			t.Fatalf("Unexpected error occured: %s", err.Error())
+		}
+		decoded, err := DecodeEvent[CartEvent](codec, current, data)
+		if err != nil {
+			t.Fatalf("Unexpected error occured: %s", err.Error())
+		}
+		if value := decoded.Data; value != (PriceChanged{Cents: 500, Currency: "EUR"}) {
+			t.Errorf("Expected Decoded '%s' Data To Equal '%+v' Actual '%+v'", codec.Name(), PriceChanged{Cents: 500, Currency: "EUR"}, value)
+		}
+		if value := decoded.Version; value != 3 {
+			t.Errorf("Expected Decoded '%s' Version To Equal '%d' Actual '%d'", codec.Name(), 3, value)
+		}
+		if value := decoded.ID; value != stored.ID {
+			t.Errorf("Expected Decoded '%s' ID To Equal '%s' Actual '%s'", codec.Name(), stored.ID, value)
+		}
+	}
+}
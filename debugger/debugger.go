@@ -0,0 +1,177 @@
+package debugger
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hijgo/go-bloc/bloc"
+	err "github.com/hijgo/go-bloc/error"
+)
+
+// Records every transition of a BloC and allows stepping through them, without changing the BloC itself.
+//
+// E : Type of events being emitted into the BloC
+//
+// S : Type of states being produced by the BloC from incoming events
+//
+// BD : BloCData Type of data that will be available to function that produces new states
+type Debugger[E any, S any, BD any] struct {
+	bloC                 *bloc.BloC[E, S, BD]
+	initialState         S
+	hasInitialState      bool
+	initialBloCData      BD
+	transitions          []bloc.Transition[E, S, BD]
+	position             int
+	removeListener       func()
+	transitionsLock      sync.RWMutex
+	followNewTransitions bool
+}
+
+// Function that should be called to start debugging a BloC.
+// Will record every transition of the BloC from now on, until Detach is called.
+//
+// E : Type of events being emitted into the BloC
+//
+// S : Type of states being produced by the BloC from incoming events
+//
+// BD : BloCData Type of data that will be available to function that produces new states
+//
+// BloC : The BloC that should be debugged
+func Attach[E any, S any, BD any](BloC *bloc.BloC[E, S, BD]) *Debugger[E, S, BD] {
+	initialState, hasInitialState := BloC.GetState()
+	d := &Debugger[E, S, BD]{
+		bloC:                 BloC,
+		initialState:         initialState,
+		hasInitialState:      hasInitialState,
+		initialBloCData:      BloC.GetBloCData(),
+		transitions:          make([]bloc.Transition[E, S, BD], 0),
+		position:             -1,
+		followNewTransitions: true,
+	}
+	d.removeListener = BloC.AddTransitionListener(d.record)
+	return d
+}
+
+func (d *Debugger[E, S, BD]) record(Transition bloc.Transition[E, S, BD]) {
+	d.transitionsLock.Lock()
+	defer d.transitionsLock.Unlock()
+	d.transitions = append(d.transitions, Transition)
+	if d.followNewTransitions {
+		d.position = len(d.transitions) - 1
+	}
+}
+
+// Stops recording new transitions. Already recorded transitions can still be inspected.
+func (d *Debugger[E, S, BD]) Detach() {
+	d.removeListener()
+}
+
+// Returns a copy of all recorded transitions, ordered from the oldest to the newest transition.
+func (d *Debugger[E, S, BD]) GetTransitions() []bloc.Transition[E, S, BD] {
+	d.transitionsLock.RLock()
+	defer d.transitionsLock.RUnlock()
+	transitions := make([]bloc.Transition[E, S, BD], len(d.transitions))
+	copy(transitions, d.transitions)
+	return transitions
+}
+
+// Returns the number of recorded transitions.
+func (d *Debugger[E, S, BD]) GetTransitionCount() int {
+	d.transitionsLock.RLock()
+	defer d.transitionsLock.RUnlock()
+	return len(d.transitions)
+}
+
+// Returns the position of the transition the debugger is currently pointing at.
+// Will return -1 if the debugger points at the point before the first recorded transition.
+func (d *Debugger[E, S, BD]) GetPosition() int {
+	d.transitionsLock.RLock()
+	defer d.transitionsLock.RUnlock()
+	return d.position
+}
+
+// Returns the transition the debugger is currently pointing at.
+// If the debugger points at the point before the first recorded transition, will return false as second value.
+func (d *Debugger[E, S, BD]) GetCurrentTransition() (bloc.Transition[E, S, BD], bool) {
+	d.transitionsLock.RLock()
+	defer d.transitionsLock.RUnlock()
+	if d.position < 0 {
+		return bloc.Transition[E, S, BD]{}, false
+	}
+	return d.transitions[d.position], true
+}
+
+// Returns the state and BloCData of the BloC at the point the debugger is currently pointing at.
+// If the BloC had no state at that point, will return false as third value.
+func (d *Debugger[E, S, BD]) GetCurrentState() (S, BD, bool) {
+	d.transitionsLock.RLock()
+	defer d.transitionsLock.RUnlock()
+	return d.stateAt(d.position)
+}
+
+func (d *Debugger[E, S, BD]) stateAt(Position int) (S, BD, bool) {
+	if Position < 0 {
+		return d.initialState, d.initialBloCData, d.hasInitialState
+	}
+	transition := d.transitions[Position]
+	return transition.NextState, transition.BloCData, true
+}
+
+// Moves the debugger one transition backwards.
+//
+// Will return an error if the debugger already points at the point before the first recorded transition.
+func (d *Debugger[E, S, BD]) StepBackward() error {
+	d.transitionsLock.Lock()
+	defer d.transitionsLock.Unlock()
+	return d.jumpTo(d.position - 1)
+}
+
+// Moves the debugger one transition forwards.
+// When the debugger points at the newest transition again, it will follow new transitions of the BloC.
+//
+// Will return an error if the debugger already points at the newest transition.
+func (d *Debugger[E, S, BD]) StepForward() error {
+	d.transitionsLock.Lock()
+	defer d.transitionsLock.Unlock()
+	return d.jumpTo(d.position + 1)
+}
+
+// Moves the debugger to the transition at the given position.
+// When the debugger points at the newest transition, it will follow new transitions of the BloC.
+//
+// Position : The position of the transition, -1 to jump to the point before the first recorded transition
+//
+// Will return an error when the given position is not inside the range of recorded transitions.
+func (d *Debugger[E, S, BD]) JumpTo(Position int) error {
+	d.transitionsLock.Lock()
+	defer d.transitionsLock.Unlock()
+	return d.jumpTo(Position)
+}
+
+func (d *Debugger[E, S, BD]) jumpTo(Position int) error {
+	if Position < -1 || Position >= len(d.transitions) {
+		return &err.Error{
+			Context: "Wanted Position not in range of recorded transitions",
+			Err:     fmt.Errorf("position '%d' out of range '%d'", Position, len(d.transitions)),
+			Code:    err.CodeOutOfRange,
+		}
+	}
+	d.position = Position
+	d.followNewTransitions = Position == len(d.transitions)-1
+	return nil
+}
+
+// Creates a new, independent BloC starting with the state and BloCData of the point the debugger is currently
+// pointing at. Neither the debugged BloC nor the recorded transitions will be changed.
+//
+// The new BloC still has to be listened to, like any other BloC.
+func (d *Debugger[E, S, BD]) Fork() bloc.BloC[E, S, BD] {
+	d.transitionsLock.RLock()
+	state, bloCData, hasState := d.stateAt(d.position)
+	d.transitionsLock.RUnlock()
+
+	if !hasState {
+		return d.bloC.Fork(bloCData, nil)
+	}
+	return d.bloC.Fork(bloCData, &state)
+}
@@ -0,0 +1,179 @@
+package debugger
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/hijgo/go-bloc/bloc"
+	blocError "github.com/hijgo/go-bloc/error"
+	"github.com/hijgo/go-bloc/event"
+)
+
+type Event struct {
+	Data int
+}
+
+type BD struct {
+	Sum int
+}
+
+type State struct {
+	State int
+}
+
+func createDebuggedBloC(t *testing.T, Events ...int) (*bloc.BloC[Event, State, BD], *Debugger[Event, State, BD]) {
+	var wg sync.WaitGroup
+	b := bloc.CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State {
+		BD.Sum += E.Data.Data
+		return State{State: E.Data.Data}
+	})
+	d := Attach(&b)
+	removeListener := b.AddTransitionListener(func(bloc.Transition[Event, State, BD]) { wg.Done() })
+	defer removeListener()
+
+	err := b.StartListenToEventStream()
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Add(len(Events))
+	for _, e := range Events {
+		if err := b.AddEvent(Event{Data: e}); err != nil {
+			t.Errorf("Unexpected error occured: %s", err.Error())
+		}
+	}
+	wg.Wait()
+	return &b, d
+}
+
+func TestAttach(t *testing.T) {
+	b, d := createDebuggedBloC(t, 1, 2, 3)
+	defer b.Dispose()
+
+	if value := d.GetTransitionCount(); value != 3 {
+		t.Fatalf("Expected GetTransitionCount To Equal '%d' Actual '%d'", 3, value)
+	}
+	if value := d.GetPosition(); value != 2 {
+		t.Errorf("Expected GetPosition To Equal '%d' Actual '%d'", 2, value)
+	}
+
+	transitions := d.GetTransitions()
+	if value := transitions[1].PreviousState.State; value != 1 {
+		t.Errorf("Expected PreviousState To Equal '%d' Actual '%d'", 1, value)
+	}
+	if value := transitions[1].NextState.State; value != 2 {
+		t.Errorf("Expected NextState To Equal '%d' Actual '%d'", 2, value)
+	}
+	if value := transitions[1].BloCData.Sum; value != 3 {
+		t.Errorf("Expected BloCData.Sum To Equal '%d' Actual '%d'", 3, value)
+	}
+	if value := transitions[0].HasPreviousState; value {
+		t.Errorf("Expected HasPreviousState To Equal '%t' Actual '%t'", false, value)
+	}
+}
+
+func TestDebugger_StepBackwardAndForward(t *testing.T) {
+	b, d := createDebuggedBloC(t, 1, 2)
+	defer b.Dispose()
+
+	if err := d.StepBackward(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if state, bloCData, _ := d.GetCurrentState(); state.State != 1 || bloCData.Sum != 1 {
+		t.Errorf("Expected GetCurrentState To Equal '%d' '%d' Actual '%d' '%d'", 1, 1, state.State, bloCData.Sum)
+	}
+
+	if err := d.StepBackward(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if _, _, hasState := d.GetCurrentState(); hasState {
+		t.Errorf("Expected GetCurrentState To Have No State")
+	}
+	if err := d.StepBackward(); err == nil {
+		t.Errorf("Expected StepBackward To Return Error Before First Transition")
+	}
+
+	if err := d.StepForward(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if transition, _ := d.GetCurrentTransition(); transition.Event.Data.Data != 1 {
+		t.Errorf("Expected Event Data To Equal '%d' Actual '%d'", 1, transition.Event.Data.Data)
+	}
+
+	if state, _ := b.GetState(); state.State != 2 {
+		t.Errorf("Expected Live State To Be Unchanged With '%d' Actual '%d'", 2, state.State)
+	}
+}
+
+func TestDebugger_JumpTo(t *testing.T) {
+	b, d := createDebuggedBloC(t, 1, 2, 3)
+	defer b.Dispose()
+
+	if err := d.JumpTo(0); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if value := d.GetPosition(); value != 0 {
+		t.Errorf("Expected GetPosition To Equal '%d' Actual '%d'", 0, value)
+	}
+
+	if err := d.JumpTo(3); !errors.Is(err, blocError.ErrOutOfRange) {
+		t.Errorf("Expected JumpTo To Return Error '%s' Actual '%v'", blocError.ErrOutOfRange, err)
+	}
+	if err := d.JumpTo(-2); err == nil {
+		t.Errorf("Expected JumpTo To Return Error When Position Out Of Range")
+	}
+}
+
+func TestDebugger_Fork(t *testing.T) {
+	var wg sync.WaitGroup
+	b, d := createDebuggedBloC(t, 1, 2, 3)
+	defer b.Dispose()
+
+	if err := d.JumpTo(0); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	forked := d.Fork()
+	defer forked.Dispose()
+
+	if state, _ := forked.GetState(); state.State != 1 {
+		t.Errorf("Expected Forked State To Equal '%d' Actual '%d'", 1, state.State)
+	}
+
+	forked.AddTransitionListener(func(bloc.Transition[Event, State, BD]) { wg.Done() })
+	if err := forked.StartListenToEventStream(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Add(1)
+	if err := forked.AddEvent(Event{Data: 10}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	if value := forked.GetBloCData().Sum; value != 11 {
+		t.Errorf("Expected Forked BloCData.Sum To Equal '%d' Actual '%d'", 11, value)
+	}
+	if value := b.GetBloCData().Sum; value != 6 {
+		t.Errorf("Expected Live BloCData.Sum To Be Unchanged With '%d' Actual '%d'", 6, value)
+	}
+	if value := d.GetTransitionCount(); value != 3 {
+		t.Errorf("Expected GetTransitionCount To Be Unchanged With '%d' Actual '%d'", 3, value)
+	}
+}
+
+func TestDebugger_Detach(t *testing.T) {
+	var wg sync.WaitGroup
+	b, d := createDebuggedBloC(t, 1)
+	defer b.Dispose()
+	b.AddTransitionListener(func(bloc.Transition[Event, State, BD]) { wg.Done() })
+
+	d.Detach()
+	wg.Add(1)
+	if err := b.AddEvent(Event{Data: 2}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	if value := d.GetTransitionCount(); value != 1 {
+		t.Errorf("Expected GetTransitionCount To Equal '%d' Actual '%d'", 1, value)
+	}
+}
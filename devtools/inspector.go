@@ -0,0 +1,382 @@
+package devtools
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hijgo/go-bloc/bloc"
+)
+
+// Default number of transitions kept per BloC by an Inspector.
+var DefaultMaxTransitions = 50
+
+// Default maximum size in bytes of an event added through an Inspector.
+var DefaultMaxEventSize int64 = 1 << 20
+
+// Size of the buffer of every Server-Sent Events subscriber, messages will be dropped for subscribers that fall behind.
+const subscriberBufferSize = 64
+
+// An http.Handler that allows inspecting all BloCs of a running program.
+// Every BloC created after the Inspector will be registered automatically, until the Inspector is closed.
+//
+// The Inspector serves the following routes, relative to the path it is mounted at:
+//
+// GET /blocs : Lists all registered BloCs with their current state
+//
+// GET /blocs/{id} : Shows the current state, BloCData, recent events and recent transitions of a BloC
+//
+// POST /blocs/{id}/events : Adds the JSON encoded event of the request body to a BloC, only if AllowEventInjection is true.
+// Answers with 413 Request Entity Too Large if the body is larger than MaxEventSize
+//
+// GET /events : Streams created and disposed BloCs as well as all transitions as Server-Sent Events. Answers with
+// 503 Service Unavailable once the Inspector is closed
+//
+// AllowEventInjection : If true, events can be added to BloCs through the Inspector. Should only be enabled for debugging
+//
+// MaxTransitions : The number of transitions kept per BloC
+//
+// MaxEventSize : The maximum size in bytes of the body of an injected event
+type Inspector struct {
+	AllowEventInjection bool
+	MaxTransitions      int
+	MaxEventSize        int64
+	lock                sync.RWMutex
+	bloCs               map[uint64]*inspectedBloC
+	subscribers         map[chan Message]struct{}
+	closed              bool
+	removeObserver      func()
+}
+
+type inspectedBloC struct {
+	bloC              bloc.Inspectable
+	transitions       []bloc.TransitionSnapshot
+	removeTransitions func()
+}
+
+// A single update sent to Server-Sent Events subscribers.
+//
+// Type : Either "created", "disposed" or "transition"
+//
+// BloCID : The id of the BloC the update belongs to
+//
+// Transition : The new transition, only set if Type is "transition"
+type Message struct {
+	Type       string                   `json:"type"`
+	BloCID     uint64                   `json:"bloCId"`
+	Transition *bloc.TransitionSnapshot `json:"transition,omitempty"`
+}
+
+// The detailed view on a single BloC, served by GET /blocs/{id}.
+//
+// Transitions : The most recent transitions of the BloC, ordered from the oldest to the newest transition
+type Details struct {
+	bloc.Snapshot
+	Transitions []bloc.TransitionSnapshot `json:"transitions"`
+}
+
+// Function that should be called if a new Inspector is needed.
+// The Inspector will register every BloC created from now on, until Close is called.
+//
+// AllowEventInjection : If true, events can be added to BloCs through the Inspector
+func CreateInspector(AllowEventInjection bool) *Inspector {
+	i := &Inspector{
+		AllowEventInjection: AllowEventInjection,
+		MaxTransitions:      DefaultMaxTransitions,
+		MaxEventSize:        DefaultMaxEventSize,
+		bloCs:               make(map[uint64]*inspectedBloC),
+		subscribers:         make(map[chan Message]struct{}),
+	}
+	i.removeObserver = bloc.AddLifecycleObserver(i)
+	return i
+}
+
+// Registers a BloC with the Inspector. Only needed for BloCs that were created before the Inspector.
+//
+// BloC : The BloC that should be inspected
+func (i *Inspector) Register(BloC bloc.Inspectable) {
+	i.lock.Lock()
+	if _, exists := i.bloCs[BloC.GetID()]; exists {
+		i.lock.Unlock()
+		return
+	}
+	inspected := &inspectedBloC{bloC: BloC}
+	i.bloCs[BloC.GetID()] = inspected
+	i.lock.Unlock()
+
+	inspected.removeTransitions = BloC.AddTransitionObserver(func(Transition bloc.TransitionSnapshot) {
+		i.recordTransition(inspected, Transition)
+	})
+	i.publish(Message{Type: "created", BloCID: BloC.GetID()})
+}
+
+// Removes a BloC from the Inspector.
+//
+// BloC : The BloC that should no longer be inspected
+func (i *Inspector) Unregister(BloC bloc.Inspectable) {
+	i.lock.Lock()
+	inspected, exists := i.bloCs[BloC.GetID()]
+	delete(i.bloCs, BloC.GetID())
+	i.lock.Unlock()
+
+	if !exists {
+		return
+	}
+	inspected.removeTransitions()
+	i.publish(Message{Type: "disposed", BloCID: BloC.GetID()})
+}
+
+// Called whenever a new BloC was created, will register the BloC.
+func (i *Inspector) OnCreate(BloC bloc.Inspectable) {
+	i.Register(BloC)
+}
+
+// Called whenever a BloC was disposed, will unregister the BloC.
+func (i *Inspector) OnDispose(BloC bloc.Inspectable) {
+	i.Unregister(BloC)
+}
+
+// Stops registering new BloCs and ends all Server-Sent Events streams. New streams are rejected from now on.
+func (i *Inspector) Close() {
+	i.removeObserver()
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.closed = true
+	for _, inspected := range i.bloCs {
+		inspected.removeTransitions()
+	}
+	for subscriber := range i.subscribers {
+		close(subscriber)
+	}
+	i.bloCs = make(map[uint64]*inspectedBloC)
+	i.subscribers = make(map[chan Message]struct{})
+}
+
+func (i *Inspector) recordTransition(Inspected *inspectedBloC, Transition bloc.TransitionSnapshot) {
+	i.lock.Lock()
+	Inspected.transitions = append(Inspected.transitions, Transition)
+	if overflow := len(Inspected.transitions) - i.MaxTransitions; overflow > 0 {
+		Inspected.transitions = Inspected.transitions[overflow:]
+	}
+	i.lock.Unlock()
+
+	i.publish(Message{Type: "transition", BloCID: Transition.BloCID, Transition: &Transition})
+}
+
+func (i *Inspector) publish(NewMessage Message) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	for subscriber := range i.subscribers {
+		select {
+		case subscriber <- NewMessage:
+		default:
+		}
+	}
+}
+
+// Returns a new subscriber, or false if the Inspector is closed.
+func (i *Inspector) subscribe() (chan Message, bool) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.closed {
+		return nil, false
+	}
+	subscriber := make(chan Message, subscriberBufferSize)
+	i.subscribers[subscriber] = struct{}{}
+	return subscriber, true
+}
+
+func (i *Inspector) unsubscribe(Subscriber chan Message) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if _, exists := i.subscribers[Subscriber]; exists {
+		delete(i.subscribers, Subscriber)
+		close(Subscriber)
+	}
+}
+
+// Returns the snapshots of all registered BloCs, ordered by their id.
+func (i *Inspector) GetSnapshots() []bloc.Snapshot {
+	i.lock.RLock()
+	bloCs := make([]bloc.Inspectable, 0, len(i.bloCs))
+	for _, inspected := range i.bloCs {
+		bloCs = append(bloCs, inspected.bloC)
+	}
+	i.lock.RUnlock()
+
+	sort.Slice(bloCs, func(a, b int) bool { return bloCs[a].GetID() < bloCs[b].GetID() })
+	snapshots := make([]bloc.Snapshot, 0, len(bloCs))
+	for _, b := range bloCs {
+		snapshot := b.Inspect()
+		snapshot.Events = nil
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
+
+// Returns the details of the BloC with the given id. If no such BloC is registered, will return false as second value.
+//
+// ID : The id of the BloC
+func (i *Inspector) GetDetails(ID uint64) (Details, bool) {
+	i.lock.RLock()
+	inspected, exists := i.bloCs[ID]
+	if !exists {
+		i.lock.RUnlock()
+		return Details{}, false
+	}
+	transitions := make([]bloc.TransitionSnapshot, len(inspected.transitions))
+	copy(transitions, inspected.transitions)
+	i.lock.RUnlock()
+
+	snapshot := inspected.bloC.Inspect()
+	if overflow := len(snapshot.Events) - i.MaxTransitions; overflow > 0 {
+		snapshot.Events = snapshot.Events[overflow:]
+	}
+	return Details{Snapshot: snapshot, Transitions: transitions}, true
+}
+
+func (i *Inspector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case len(segments) == 1 && segments[0] == "blocs" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, i.GetSnapshots())
+	case len(segments) == 1 && segments[0] == "events" && r.Method == http.MethodGet:
+		i.serveEvents(w, r)
+	case len(segments) == 2 && segments[0] == "blocs" && r.Method == http.MethodGet:
+		id, ok := parseID(w, segments[1])
+		if !ok {
+			return
+		}
+		details, exists := i.GetDetails(id)
+		if !exists {
+			writeError(w, http.StatusNotFound, fmt.Errorf("bloc '%d' not found", id))
+			return
+		}
+		writeJSON(w, http.StatusOK, details)
+	case len(segments) == 3 && segments[0] == "blocs" && segments[2] == "events" && r.Method == http.MethodPost:
+		id, ok := parseID(w, segments[1])
+		if !ok {
+			return
+		}
+		i.injectEvent(w, r, id)
+	default:
+		writeError(w, http.StatusNotFound, fmt.Errorf("route '%s %s' not found", r.Method, r.URL.Path))
+	}
+}
+
+func (i *Inspector) injectEvent(w http.ResponseWriter, r *http.Request, ID uint64) {
+	if !i.AllowEventInjection {
+		writeError(w, http.StatusForbidden, fmt.Errorf("event injection is disabled"))
+		return
+	}
+
+	i.lock.RLock()
+	inspected, exists := i.bloCs[ID]
+	i.lock.RUnlock()
+	if !exists {
+		writeError(w, http.StatusNotFound, fmt.Errorf("bloc '%d' not found", ID))
+		return
+	}
+
+	limit := i.MaxEventSize
+	counter := &countingReader{reader: r.Body}
+	body, readErr := io.ReadAll(http.MaxBytesReader(w, io.NopCloser(counter), limit))
+	if readErr != nil && counter.count > limit {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("event is larger than %d bytes", limit))
+		return
+	}
+	if readErr != nil {
+		writeError(w, http.StatusBadRequest, readErr)
+		return
+	}
+	if addErr := inspected.bloC.AddEventFromJSON(body); addErr != nil {
+		writeError(w, http.StatusBadRequest, addErr)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (i *Inspector) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("streaming is not supported"))
+		return
+	}
+
+	subscriber, subscribed := i.subscribe()
+	if !subscribed {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("inspector is closed"))
+		return
+	}
+	defer i.unsubscribe(subscriber)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case message, open := <-subscriber:
+			if !open {
+				return
+			}
+			data, encodeErr := json.Marshal(message)
+			if encodeErr != nil {
+				data, _ = json.Marshal(Message{Type: message.Type, BloCID: message.BloCID})
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", message.Type, data)
+			flusher.Flush()
+		}
+	}
+}
+
+// Counts the bytes read from the body, so a body exceeding the limit of http.MaxBytesReader can be told apart from
+// other read errors.
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (c *countingReader) Read(Buffer []byte) (int, error) {
+	n, readErr := c.reader.Read(Buffer)
+	c.count += int64(n)
+	return n, readErr
+}
+
+func parseID(w http.ResponseWriter, Segment string) (uint64, bool) {
+	id, parseErr := strconv.ParseUint(Segment, 10, 64)
+	if parseErr != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid bloc id '%s'", Segment))
+		return 0, false
+	}
+	return id, true
+}
+
+func writeJSON(w http.ResponseWriter, Status int, Value any) {
+	data, encodeErr := json.Marshal(Value)
+	if encodeErr != nil {
+		writeError(w, http.StatusInternalServerError, encodeErr)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(Status)
+	_, _ = w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, Status int, Err error) {
+	data, _ := json.Marshal(map[string]string{"error": Err.Error()})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(Status)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,246 @@
+package devtools
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/bloc"
+	"github.com/hijgo/go-bloc/event"
+)
+
+type Event struct {
+	Data int
+}
+
+type BD struct {
+	Sum int
+}
+
+type State struct {
+	State int
+}
+
+func createBloC(t *testing.T) (*bloc.BloC[Event, State, BD], *sync.WaitGroup) {
+	var wg sync.WaitGroup
+	b := bloc.CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State {
+		BD.Sum += E.Data.Data
+		return State{State: E.Data.Data}
+	})
+	b.AddTransitionListener(func(bloc.Transition[Event, State, BD]) { wg.Done() })
+	if err := b.StartListenToEventStream(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	return &b, &wg
+}
+
+func TestCreateInspector(t *testing.T) {
+	inspector := CreateInspector(false)
+	defer inspector.Close()
+
+	b, _ := createBloC(t)
+	if _, exists := inspector.GetDetails(b.GetID()); !exists {
+		t.Errorf("Expected Created BloC To Be Registered")
+	}
+
+	b.Dispose()
+	if _, exists := inspector.GetDetails(b.GetID()); exists {
+		t.Errorf("Expected Disposed BloC To Be Unregistered")
+	}
+}
+
+func TestInspector_ListBloCs(t *testing.T) {
+	inspector := CreateInspector(false)
+	defer inspector.Close()
+	b, wg := createBloC(t)
+	defer b.Dispose()
+	b.SetName("counter")
+
+	wg.Add(1)
+	if err := b.AddEvent(Event{Data: 3}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	recorder := httptest.NewRecorder()
+	inspector.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/blocs", nil))
+	if value := recorder.Code; value != http.StatusOK {
+		t.Fatalf("Expected Status To Equal '%d' Actual '%d'", http.StatusOK, value)
+	}
+
+	var snapshots []bloc.Snapshot
+	if err := json.Unmarshal(recorder.Body.Bytes(), &snapshots); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if value := len(snapshots); value != 1 {
+		t.Fatalf("Expected len(snapshots) To Equal '%d' Actual '%d'", 1, value)
+	}
+	if value := snapshots[0].Name; value != "counter" {
+		t.Errorf("Expected Name To Equal '%s' Actual '%s'", "counter", value)
+	}
+	if value := snapshots[0].State.(map[string]any)["State"]; value != float64(3) {
+		t.Errorf("Expected State To Equal '%d' Actual '%v'", 3, value)
+	}
+}
+
+func TestInspector_BloCDetails(t *testing.T) {
+	inspector := CreateInspector(false)
+	defer inspector.Close()
+	b, wg := createBloC(t)
+	defer b.Dispose()
+
+	wg.Add(2)
+	if err := b.AddEvent(Event{Data: 1}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	if err := b.AddEvent(Event{Data: 2}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	recorder := httptest.NewRecorder()
+	inspector.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/blocs/"+idOf(b), nil))
+	if value := recorder.Code; value != http.StatusOK {
+		t.Fatalf("Expected Status To Equal '%d' Actual '%d'", http.StatusOK, value)
+	}
+
+	var details Details
+	if err := json.Unmarshal(recorder.Body.Bytes(), &details); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if value := len(details.Transitions); value != 2 {
+		t.Errorf("Expected len(Transitions) To Equal '%d' Actual '%d'", 2, value)
+	}
+	if value := len(details.Events); value != 2 {
+		t.Errorf("Expected len(Events) To Equal '%d' Actual '%d'", 2, value)
+	}
+	if value := details.BloCData.(map[string]any)["Sum"]; value != float64(3) {
+		t.Errorf("Expected BloCData.Sum To Equal '%d' Actual '%v'", 3, value)
+	}
+
+	recorder = httptest.NewRecorder()
+	inspector.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/blocs/0", nil))
+	if value := recorder.Code; value != http.StatusNotFound {
+		t.Errorf("Expected Status To Equal '%d' Actual '%d'", http.StatusNotFound, value)
+	}
+}
+
+func TestInspector_InjectEvent(t *testing.T) {
+	inspector := CreateInspector(false)
+	defer inspector.Close()
+	b, wg := createBloC(t)
+	defer b.Dispose()
+
+	recorder := httptest.NewRecorder()
+	inspector.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/blocs/"+idOf(b)+"/events", strings.NewReader(`{"Data":5}`)))
+	if value := recorder.Code; value != http.StatusForbidden {
+		t.Errorf("Expected Status To Equal '%d' Actual '%d'", http.StatusForbidden, value)
+	}
+
+	inspector.AllowEventInjection = true
+	recorder = httptest.NewRecorder()
+	inspector.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/blocs/"+idOf(b)+"/events", strings.NewReader(`{"Data":`)))
+	if value := recorder.Code; value != http.StatusBadRequest {
+		t.Errorf("Expected Status To Equal '%d' Actual '%d'", http.StatusBadRequest, value)
+	}
+
+	wg.Add(1)
+	recorder = httptest.NewRecorder()
+	inspector.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/blocs/"+idOf(b)+"/events", strings.NewReader(`{"Data":5}`)))
+	if value := recorder.Code; value != http.StatusAccepted {
+		t.Fatalf("Expected Status To Equal '%d' Actual '%d'", http.StatusAccepted, value)
+	}
+	wg.Wait()
+	if state, _ := b.GetState(); state.State != 5 {
+		t.Errorf("Expected State To Equal '%d' Actual '%d'", 5, state.State)
+	}
+}
+
+func TestInspector_InjectEventShouldRejectTooLargeBody(t *testing.T) {
+	inspector := CreateInspector(true)
+	defer inspector.Close()
+	b, _ := createBloC(t)
+	defer b.Dispose()
+
+	body := `{"Data":5}`
+	inspector.MaxEventSize = int64(len(body)) - 1
+	recorder := httptest.NewRecorder()
+	inspector.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/blocs/"+idOf(b)+"/events", strings.NewReader(body)))
+	if value := recorder.Code; value != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected Status To Equal '%d' Actual '%d'", http.StatusRequestEntityTooLarge, value)
+	}
+	if value := len(b.GetEventHistory()); value != 0 {
+		t.Errorf("Expected len(GetEventHistory) To Equal '%d' Actual '%d'", 0, value)
+	}
+}
+
+func TestInspector_ServerSentEventsShouldBeRejectedAfterClose(t *testing.T) {
+	inspector := CreateInspector(false)
+	server := httptest.NewServer(inspector)
+	defer server.Close()
+	inspector.Close()
+
+	client := http.Client{Timeout: 5 * time.Second}
+	response, err := client.Get(server.URL + "/events")
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	defer response.Body.Close()
+	if value := response.StatusCode; value != http.StatusServiceUnavailable {
+		t.Errorf("Expected StatusCode To Equal '%d' Actual '%d'", http.StatusServiceUnavailable, value)
+	}
+}
+
+func TestInspector_ServerSentEvents(t *testing.T) {
+	inspector := CreateInspector(false)
+	defer inspector.Close()
+	server := httptest.NewServer(inspector)
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/events")
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	defer response.Body.Close()
+	if value := response.Header.Get("Content-Type"); value != "text/event-stream" {
+		t.Errorf("Expected Content-Type To Equal '%s' Actual '%s'", "text/event-stream", value)
+	}
+
+	b, wg := createBloC(t)
+	defer b.Dispose()
+	wg.Add(1)
+	if err := b.AddEvent(Event{Data: 1}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	reader := bufio.NewReader(response.Body)
+	for _, expected := range []string{"event: created", "event: transition"} {
+		line := readEventLine(t, reader)
+		if line != expected {
+			t.Errorf("Expected Line To Equal '%s' Actual '%s'", expected, line)
+		}
+	}
+}
+
+func readEventLine(t *testing.T, Reader *bufio.Reader) string {
+	for {
+		line, err := Reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Unexpected error occured: %s", err.Error())
+		}
+		if strings.HasPrefix(line, "event: ") {
+			return strings.TrimSpace(line)
+		}
+	}
+}
+
+func idOf(BloC bloc.Inspectable) string {
+	return strconv.FormatUint(BloC.GetID(), 10)
+}
@@ -1,10 +1,105 @@
 package error
 
+import "errors"
+
+// Error returned by all packages of this module.
+//
+// Context : Describes what could not be done
+//
+// Err : The cause of the error, its message is the message of the Error
+//
+// Code : Classifies the error, so callers can react to a kind of failure using errors.Is with one of the sentinel
+// errors instead of comparing messages. Empty if the error is not classified
 type Error struct {
 	Context string
 	Err     error
+	Code    Code
 }
 
+// Classifies an Error, see the sentinel errors for the available codes.
+type Code string
+
+// Codes of the sentinel errors.
+const (
+	CodeAlreadyListening Code = "already_listening"
+	CodeNotListening     Code = "not_listening"
+	CodeDisposed         Code = "disposed"
+	CodeOutOfRange       Code = "out_of_range"
+	CodeCycle            Code = "cycle"
+	CodeInvalidArgument  Code = "invalid_argument"
+	CodeDecode           Code = "decode"
+	CodeTooLarge         Code = "too_large"
+	CodeDeduplication    Code = "deduplication"
+)
+
+// Sentinel errors, an Error matches the sentinel with the same Code when compared with errors.Is.
+var (
+	// A stream or BloC is already listened to
+	ErrAlreadyListening = &Error{Code: CodeAlreadyListening, Err: errors.New("already listened to")}
+	// A stream or BloC is not listened to
+	ErrNotListening = &Error{Code: CodeNotListening, Err: errors.New("not listened to")}
+	// A stream or BloC was already disposed
+	ErrDisposed = &Error{Code: CodeDisposed, Err: errors.New("was disposed")}
+	// A position or range is outside of a history
+	ErrOutOfRange = &Error{Code: CodeOutOfRange, Err: errors.New("out of range")}
+	// A dependency between BloCs would create a cycle
+	ErrCycle = &Error{Code: CodeCycle, Err: errors.New("creates a cycle")}
+	// An argument has an invalid value
+	ErrInvalidArgument = &Error{Code: CodeInvalidArgument, Err: errors.New("invalid argument")}
+	// Data could not be decoded
+	ErrDecode = &Error{Code: CodeDecode, Err: errors.New("cannot decode")}
+	// Data exceeds the maximum size that is accepted
+	ErrTooLarge = &Error{Code: CodeTooLarge, Err: errors.New("too large")}
+	// The store remembering the keys of added events failed
+	ErrDeduplication = &Error{Code: CodeDeduplication, Err: errors.New("deduplication failed")}
+)
+
+// Returns the message of the cause of the error.
 func (e Error) Error() string {
 	return e.Err.Error()
 }
+
+// Returns the cause of the error, so errors.Is and errors.As can inspect it.
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// Returns true if the target is an Error with the same, non empty Code, used by errors.Is to match sentinel errors.
+//
+// Target : The error compared with
+func (e Error) Is(Target error) bool {
+	switch target := Target.(type) {
+	case *Error:
+		return target != nil && e.Code != "" && e.Code == target.Code
+	case Error:
+		return e.Code != "" && e.Code == target.Code
+	}
+	return false
+}
+
+// Returns the first non empty Code of an Error in the chain of the given error, empty if there is none.
+//
+// Err : The error whose code should be returned
+func CodeOf(Err error) Code {
+	for Err != nil {
+		switch current := Err.(type) {
+		case *Error:
+			if current == nil {
+				return ""
+			}
+			if current.Code != "" {
+				return current.Code
+			}
+		case Error:
+			if current.Code != "" {
+				return current.Code
+			}
+		}
+		unwrapper, ok := Err.(interface{ Unwrap() error })
+		if !ok {
+			return ""
+		}
+		Err = unwrapper.Unwrap()
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+package error
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestError_Is(t *testing.T) {
+	cause := errors.New("cause")
+	wrapped := &Error{Context: "Cannot listen to stream!", Err: fmt.Errorf("stream already listened to: %w", cause), Code: CodeAlreadyListening}
+
+	if !errors.Is(wrapped, ErrAlreadyListening) {
+		t.Errorf("Expected Error To Match '%s'", ErrAlreadyListening.Code)
+	}
+	if errors.Is(wrapped, ErrDisposed) {
+		t.Errorf("Expected Error Not To Match '%s'", ErrDisposed.Code)
+	}
+	if !errors.Is(wrapped, cause) {
+		t.Errorf("Expected Error To Unwrap To Its Cause")
+	}
+	if !errors.Is(fmt.Errorf("outer: %w", wrapped), ErrAlreadyListening) {
+		t.Errorf("Expected Wrapped Error To Match '%s'", ErrAlreadyListening.Code)
+	}
+	if value := wrapped.Error(); value != "stream already listened to: cause" {
+		t.Errorf("Expected Error To Equal '%s' Actual '%s'", "stream already listened to: cause", value)
+	}
+
+	unclassified := &Error{Context: "Unclassified!", Err: cause}
+	if errors.Is(unclassified, &Error{}) {
+		t.Errorf("Expected Unclassified Error Not To Match Unclassified Target")
+	}
+
+	var target *Error
+	if !errors.As(fmt.Errorf("outer: %w", wrapped), &target) || target.Context != wrapped.Context {
+		t.Errorf("Expected errors.As To Return The Wrapped Error")
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	cases := []struct {
+		Err      error
+		Expected Code
+	}{
+		{nil, ""},
+		{errors.New("plain"), ""},
+		{&Error{Err: errors.New("unclassified")}, ""},
+		{&Error{Err: errors.New("range"), Code: CodeOutOfRange}, CodeOutOfRange},
+		{Error{Err: errors.New("range"), Code: CodeOutOfRange}, CodeOutOfRange},
+		{fmt.Errorf("outer: %w", &Error{Err: ErrDisposed}), CodeDisposed},
+	}
+	for i, c := range cases {
+		if value := CodeOf(c.Err); value != c.Expected {
+			t.Errorf("Expected CodeOf Case '%d' To Equal '%s' Actual '%s'", i, c.Expected, value)
+		}
+	}
+}
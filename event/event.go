@@ -6,12 +6,22 @@ import "time"
 //
 // T : The type of data carried with by the event struct.
 //
-// TimeStamp : Time of creation
+// TimeStamp : Time of creation in milliseconds since epoch, use Time for a precise time of creation
+//
+// Time : Time of creation with nanosecond precision
+//
+// Sequence : Position of the event in the stream it was added to, assigned by stream.Stream.Add and starting at 1.
+// 0 if the event was not added to a stream yet
 //
 // Data : Additional data associated with the event.
+//
+// Metadata : Id, correlation, causation, source and headers of the event
 type Event[T any] struct {
 	TimeStamp int64
+	Time      time.Time
+	Sequence  uint64
 	Data      T
+	Metadata
 }
 
 // Function that will create a new Event[T] and then return it.
@@ -19,9 +29,101 @@ type Event[T any] struct {
 // T : The type of data carried with by the event struct.
 //
 // Data : Additional data associated with the new event.
-func CreateEvent[T any](Data T) Event[T] {
+//
+// Options : Options setting the Metadata of the new event, a new id will be generated if none is given
+func CreateEvent[T any](Data T, Options ...Option) Event[T] {
+	return CreateEventAt(Data, time.Now(), Options...)
+}
+
+// Function that will create a new Event[T] with the given time of creation and then return it, for example to use
+// the time of a clock.Clock instead of the system time.
+//
+// T : The type of data carried with by the event struct.
+//
+// Data : Additional data associated with the new event.
+//
+// CreatedAt : Time of creation of the new event, unless set by WithCreatedAt
+//
+// Options : Options setting the Metadata of the new event, a new id will be generated if none is given
+func CreateEventAt[T any](Data T, CreatedAt time.Time, Options ...Option) Event[T] {
+	metadata := createMetadata(Options)
+	if !metadata.createdAt.IsZero() {
+		CreatedAt, metadata.createdAt = metadata.createdAt, time.Time{}
+	}
 	return Event[T]{
-		TimeStamp: time.Now().UnixNano() / int64(time.Millisecond),
+		TimeStamp: CreatedAt.UnixNano() / int64(time.Millisecond),
+		Time:      CreatedAt,
 		Data:      Data,
+		Metadata:  metadata,
+	}
+}
+
+// Returns the time of creation of the event. Falls back to TimeStamp for events created without Time.
+func (e Event[T]) CreatedAt() time.Time {
+	if e.Time.IsZero() {
+		return time.UnixMilli(e.TimeStamp)
+	}
+	return e.Time
+}
+
+// Returns the time the event expires, which is the earlier of its Deadline and its creation plus TTL. Returns the zero
+// time if the event does not expire.
+func (e Event[T]) ExpiresAt() time.Time {
+	expiresAt := e.Deadline
+	if e.TTL > 0 {
+		if ttl := e.CreatedAt().Add(e.TTL); expiresAt.IsZero() || ttl.Before(expiresAt) {
+			expiresAt = ttl
+		}
+	}
+	return expiresAt
+}
+
+// Returns true if the event expired at the given time, used by stream.Stream to drop expired events.
+//
+// Now : The current time
+func (e Event[T]) IsExpired(Now time.Time) bool {
+	expiresAt := e.ExpiresAt()
+	return !expiresAt.IsZero() && !Now.Before(expiresAt)
+}
+
+// Returns the priority of the event, used by stream.Stream to pick the lane of the event.
+func (e Event[T]) GetPriority() int {
+	return e.Priority
+}
+
+// Returns a copy of the event with the given sequence number, used by stream.Stream.Add.
+//
+// Sequence : The position of the event in its stream
+func (e Event[T]) WithSequence(Sequence uint64) Event[T] {
+	e.Sequence = Sequence
+	return e
+}
+
+// A range of sequence numbers missing in a list of events, both inclusive.
+type Gap struct {
+	From uint64
+	To   uint64
+}
+
+// Returns all ranges of sequence numbers missing between the first and the last event, for example because events
+// were evicted from a history or lost in transport. Events without sequence number are ignored.
+//
+// T : The type of data carried with by the events.
+//
+// Events : The events, ordered by their sequence number
+func Gaps[T any](Events []Event[T]) []Gap {
+	gaps := make([]Gap, 0)
+	var previous uint64
+	for _, e := range Events {
+		if e.Sequence == 0 {
+			continue
+		}
+		if previous != 0 && e.Sequence > previous+1 {
+			gaps = append(gaps, Gap{From: previous + 1, To: e.Sequence - 1})
+		}
+		if e.Sequence > previous {
+			previous = e.Sequence
+		}
 	}
+	return gaps
 }
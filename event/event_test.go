@@ -1,6 +1,9 @@
 package event
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestCreateEvent(t *testing.T) {
 	event := CreateEvent[int](1)
@@ -8,3 +11,76 @@ func TestCreateEvent(t *testing.T) {
 		t.Errorf("Expected Field Data To Equal '%d' Actual '%d'", 1, value)
 	}
 }
+
+func TestCreateEventAt(t *testing.T) {
+	event := CreateEventAt[int](1, time.UnixMilli(1500))
+	if value := event.TimeStamp; value != 1500 {
+		t.Errorf("Expected Field TimeStamp To Equal '%d' Actual '%d'", 1500, value)
+	}
+	if value := event.Data; value != 1 {
+		t.Errorf("Expected Field Data To Equal '%d' Actual '%d'", 1, value)
+	}
+}
+
+func TestEvent_CreatedAt(t *testing.T) {
+	at := time.Unix(1, 123456789)
+	if value := CreateEventAt(1, at).CreatedAt(); !value.Equal(at) {
+		t.Errorf("Expected CreatedAt To Equal '%s' Actual '%s'", at, value)
+	}
+	legacy := Event[int]{TimeStamp: 1500, Data: 1}
+	if value := legacy.CreatedAt(); !value.Equal(time.UnixMilli(1500)) {
+		t.Errorf("Expected CreatedAt To Equal '%s' Actual '%s'", time.UnixMilli(1500), value)
+	}
+}
+
+func TestEvent_WithSequence(t *testing.T) {
+	e := CreateEvent(1)
+	sequenced := e.WithSequence(7)
+	if value := sequenced.Sequence; value != 7 {
+		t.Errorf("Expected Field Sequence To Equal '%d' Actual '%d'", 7, value)
+	}
+	if value := e.Sequence; value != 0 {
+		t.Errorf("Expected Original Sequence To Equal '%d' Actual '%d'", 0, value)
+	}
+}
+
+func TestGaps(t *testing.T) {
+	events := []Event[int]{{Sequence: 2}, {Sequence: 3}, {Sequence: 0}, {Sequence: 6}, {Sequence: 7}, {Sequence: 9}}
+	gaps := Gaps(events)
+	expected := []Gap{{From: 4, To: 5}, {From: 8, To: 8}}
+	if len(gaps) != len(expected) || gaps[0] != expected[0] || gaps[1] != expected[1] {
+		t.Errorf("Expected Gaps To Equal '%v' Actual '%v'", expected, gaps)
+	}
+	if value := len(Gaps([]Event[int]{{Sequence: 1}, {Sequence: 2}})); value != 0 {
+		t.Errorf("Expected len(Gaps) To Equal '%d' Actual '%d'", 0, value)
+	}
+}
+
+func TestEvent_IsExpired(t *testing.T) {
+	createdAt := time.Unix(100, 0)
+	cases := []struct {
+		Event     Event[int]
+		ExpiresAt time.Time
+	}{
+		{CreateEventAt(1, createdAt), time.Time{}},
+		{CreateEventAt(1, createdAt, WithTTL(time.Second)), createdAt.Add(time.Second)},
+		{CreateEventAt(1, createdAt, WithDeadline(createdAt.Add(time.Minute))), createdAt.Add(time.Minute)},
+		{CreateEventAt(1, createdAt, WithDeadline(createdAt.Add(time.Minute)), WithTTL(time.Second)), createdAt.Add(time.Second)},
+		{CreateEventAt(1, createdAt, WithDeadline(createdAt.Add(time.Second)), WithTTL(time.Minute)), createdAt.Add(time.Second)},
+	}
+	for i, c := range cases {
+		if value := c.Event.ExpiresAt(); !value.Equal(c.ExpiresAt) {
+			t.Errorf("Expected ExpiresAt Of Case '%d' To Equal '%s' Actual '%s'", i, c.ExpiresAt, value)
+		}
+		if value := c.Event.IsExpired(createdAt.Add(time.Hour)); value != !c.ExpiresAt.IsZero() {
+			t.Errorf("Expected IsExpired Of Case '%d' To Equal '%t' Actual '%t'", i, !c.ExpiresAt.IsZero(), value)
+		}
+		if value := c.Event.IsExpired(createdAt); value {
+			t.Errorf("Expected IsExpired Of Case '%d' At Creation To Equal '%t' Actual '%t'", i, false, value)
+		}
+	}
+
+	if value := CreateEvent(1, WithPriority(3)).GetPriority(); value != 3 {
+		t.Errorf("Expected GetPriority To Equal '%d' Actual '%d'", 3, value)
+	}
+}
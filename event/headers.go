@@ -0,0 +1,120 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Arbitrary additional values of an event, see WithHeader. Headers are immutable and stored as comparable value, so
+// events can still be compared with == and used as map keys. Equal headers always compare equal, the zero value holds
+// no headers.
+type Headers struct {
+	// The headers encoded as JSON object, which sorts the keys, empty if there are no headers
+	encoded string
+}
+
+// Function that should be called if Headers holding the given values are needed.
+//
+// Values : The keys and values of the headers, may be nil
+func CreateHeaders(Values map[string]string) Headers {
+	if len(Values) == 0 {
+		return Headers{}
+	}
+	encoded, encodeErr := json.Marshal(Values)
+	if encodeErr != nil {
+		panic(fmt.Errorf("cannot encode headers: %w", encodeErr))
+	}
+	return Headers{encoded: string(encoded)}
+}
+
+// Returns the value of the header with the given key, empty if there is no such header.
+//
+// Key : The key of the header
+func (h Headers) Get(Key string) string {
+	value, _ := h.Lookup(Key)
+	return value
+}
+
+// Returns the value of the header with the given key and true, or false if there is no such header.
+//
+// Key : The key of the header
+func (h Headers) Lookup(Key string) (string, bool) {
+	value, exists := h.ToMap()[Key]
+	return value, exists
+}
+
+// Returns the number of headers.
+func (h Headers) Len() int {
+	return len(h.ToMap())
+}
+
+// Returns a copy of the headers with the header of the given key set to the value.
+//
+// Key : The key of the header
+//
+// Value : The value of the header
+func (h Headers) With(Key string, Value string) Headers {
+	values := h.ToMap()
+	if values == nil {
+		values = make(map[string]string)
+	}
+	values[Key] = Value
+	return CreateHeaders(values)
+}
+
+// Returns a copy of the headers without the header of the given key.
+//
+// Key : The key of the header that should be removed
+func (h Headers) Without(Key string) Headers {
+	values := h.ToMap()
+	delete(values, Key)
+	return CreateHeaders(values)
+}
+
+// Returns a new map holding all headers, nil if there are no headers. Changing the map does not change the headers.
+func (h Headers) ToMap() map[string]string {
+	if h.encoded == "" {
+		return nil
+	}
+	values := make(map[string]string)
+	if decodeErr := json.Unmarshal([]byte(h.encoded), &values); decodeErr != nil {
+		panic(fmt.Errorf("cannot decode headers: %w", decodeErr))
+	}
+	return values
+}
+
+func (h Headers) String() string {
+	return fmt.Sprint(h.ToMap())
+}
+
+// Encodes the headers as JSON object, null if there are no headers.
+func (h Headers) MarshalJSON() ([]byte, error) {
+	if h.encoded == "" {
+		return []byte("null"), nil
+	}
+	return []byte(h.encoded), nil
+}
+
+// Decodes headers encoded as JSON object.
+func (h *Headers) UnmarshalJSON(Data []byte) error {
+	var values map[string]string
+	if decodeErr := json.Unmarshal(Data, &values); decodeErr != nil {
+		return decodeErr
+	}
+	*h = CreateHeaders(values)
+	return nil
+}
+
+// Encodes the headers for encoding/gob.
+func (h Headers) GobEncode() ([]byte, error) {
+	return []byte(h.encoded), nil
+}
+
+// Decodes headers encoded by GobEncode.
+func (h *Headers) GobDecode(Data []byte) error {
+	if len(Data) == 0 {
+		*h = Headers{}
+		return nil
+	}
+	return h.UnmarshalJSON(Data)
+}
@@ -0,0 +1,78 @@
+package event
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestHeaders_ShouldKeepEventsComparable(t *testing.T) {
+	first := CreateEvent(1, WithID("id"), WithHeader("tenant", "a"), WithHeader("user", "b"))
+	second := first
+	second.Headers = CreateHeaders(map[string]string{"user": "b", "tenant": "a"})
+
+	if first != second {
+		t.Errorf("Expected Events With Equal Headers To Be Equal")
+	}
+	seen := map[Event[int]]bool{first: true}
+	if !seen[second] {
+		t.Errorf("Expected Event To Be Usable As Map Key")
+	}
+	if second.Headers = second.Headers.With("tenant", "b"); first == second {
+		t.Errorf("Expected Events With Different Headers Not To Be Equal")
+	}
+	if value := second.Headers.Without("tenant").Without("user"); value != (Headers{}) {
+		t.Errorf("Expected Headers Without All Keys To Equal Zero Value Actual '%v'", value)
+	}
+}
+
+func TestHeaders_Lookup(t *testing.T) {
+	headers := CreateHeaders(map[string]string{"tenant": "a", "empty": ""})
+	if value, exists := headers.Lookup("empty"); !exists || value != "" {
+		t.Errorf("Expected Lookup To Return '%s', '%t' Actual '%s', '%t'", "", true, value, exists)
+	}
+	if _, exists := headers.Lookup("missing"); exists {
+		t.Errorf("Expected Lookup To Return '%t' For Missing Header", false)
+	}
+	if value := headers.Len(); value != 2 {
+		t.Errorf("Expected Len To Equal '%d' Actual '%d'", 2, value)
+	}
+	copied := headers.ToMap()
+	copied["tenant"] = "b"
+	if value := headers.Get("tenant"); value != "a" {
+		t.Errorf("Expected Headers To Be Unchanged Actual '%s'", value)
+	}
+}
+
+func TestHeaders_Encoding(t *testing.T) {
+	original := CreateEvent(1, WithID("id"), WithHeader("tenant", "a"))
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if !bytes.Contains(data, []byte(`"Headers":{"tenant":"a"}`)) {
+		t.Errorf("Expected JSON To Contain Headers As Object Actual '%s'", data)
+	}
+	var fromJSON Event[int]
+	if err := json.Unmarshal(data, &fromJSON); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if !reflect.DeepEqual(fromJSON.Headers, original.Headers) {
+		t.Errorf("Expected Headers To Equal '%v' Actual '%v'", original.Headers, fromJSON.Headers)
+	}
+
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(original); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	var fromGob Event[int]
+	if err := gob.NewDecoder(&buffer).Decode(&fromGob); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if fromGob.Headers != original.Headers {
+		t.Errorf("Expected Headers To Equal '%v' Actual '%v'", original.Headers, fromGob.Headers)
+	}
+}
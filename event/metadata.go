@@ -0,0 +1,167 @@
+package event
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Describes where an event comes from and how it relates to other events, for example for tracing and idempotency.
+//
+// ID : Unique id of the event, generated by CreateEvent if not set by an Option
+//
+// CorrelationID : Id shared by all events of the same chain, the id of the first event of the chain
+//
+// CausationID : Id of the event that directly caused this event, empty for the first event of a chain
+//
+// Source : Describes the origin of the event, for example the name of a service or BloC
+//
+// Headers : Arbitrary additional values, comparable so events can be compared with ==
+//
+// Version : Schema version of the data of the event, 0 if the data is not versioned
+//
+// Priority : Events with a higher priority are processed before pending events with a lower priority, if the stream
+// uses priority lanes. 0 by default, may be negative
+//
+// Deadline : Time after which the event is dropped instead of being processed, zero if the event does not expire
+//
+// TTL : Duration after creation after which the event is dropped instead of being processed, 0 if the event does not
+// expire
+type Metadata struct {
+	ID            string
+	CorrelationID string
+	CausationID   string
+	Source        string
+	Headers       Headers
+	Version       uint
+	Priority      int
+	Deadline      time.Time
+	TTL           time.Duration
+	// Time of creation set by WithCreatedAt, only used while creating the event
+	createdAt time.Time
+}
+
+// Sets a value of the Metadata of an event created by CreateEvent.
+type Option func(Metadata *Metadata)
+
+// Sets the id of the event, instead of generating a new one.
+//
+// ID : The id of the event
+func WithID(ID string) Option {
+	return func(Metadata *Metadata) { Metadata.ID = ID }
+}
+
+// Sets the correlation id of the event.
+//
+// CorrelationID : The id of the chain the event belongs to
+func WithCorrelationID(CorrelationID string) Option {
+	return func(Metadata *Metadata) { Metadata.CorrelationID = CorrelationID }
+}
+
+// Sets the causation id of the event.
+//
+// CausationID : The id of the event that caused the event
+func WithCausationID(CausationID string) Option {
+	return func(Metadata *Metadata) { Metadata.CausationID = CausationID }
+}
+
+// Sets the source of the event.
+//
+// Source : The origin of the event
+func WithSource(Source string) Option {
+	return func(Metadata *Metadata) { Metadata.Source = Source }
+}
+
+// Sets the schema version of the data of the event.
+//
+// Version : The version of the data, starting at 1
+func WithVersion(Version uint) Option {
+	return func(Metadata *Metadata) { Metadata.Version = Version }
+}
+
+// Sets the priority of the event.
+//
+// Priority : The priority of the event, higher priorities are processed first
+func WithPriority(Priority int) Option {
+	return func(Metadata *Metadata) { Metadata.Priority = Priority }
+}
+
+// Sets the time after which the event expires.
+//
+// Deadline : The time after which the event is dropped instead of being processed
+func WithDeadline(Deadline time.Time) Option {
+	return func(Metadata *Metadata) { Metadata.Deadline = Deadline }
+}
+
+// Sets the time of creation of the event, instead of the time the event is created at, for example to keep the time of
+// an event received from another system.
+//
+// CreatedAt : The time of creation of the event, ignored if zero
+func WithCreatedAt(CreatedAt time.Time) Option {
+	return func(Metadata *Metadata) { Metadata.createdAt = CreatedAt }
+}
+
+// Sets the duration after creation after which the event expires.
+//
+// TTL : The time the event is valid for after it was created
+func WithTTL(TTL time.Duration) Option {
+	return func(Metadata *Metadata) { Metadata.TTL = TTL }
+}
+
+// Sets a single header of the event.
+//
+// Key : The key of the header
+//
+// Value : The value of the header
+func WithHeader(Key string, Value string) Option {
+	return func(Metadata *Metadata) { Metadata.Headers = Metadata.Headers.With(Key, Value) }
+}
+
+// Marks the event as follow-up event of the given cause. The event will share the correlation id of the cause and
+// use the id of the cause as causation id.
+//
+// Cause : The Metadata of the event that caused the new event
+func CausedBy(Cause Metadata) Option {
+	return func(Metadata *Metadata) {
+		Metadata.CorrelationID = Cause.CorrelationID
+		if Metadata.CorrelationID == "" {
+			Metadata.CorrelationID = Cause.ID
+		}
+		Metadata.CausationID = Cause.ID
+	}
+}
+
+func createMetadata(Options []Option) Metadata {
+	metadata := Metadata{}
+	for _, option := range Options {
+		option(&metadata)
+	}
+	if metadata.ID == "" {
+		metadata.ID = NewID()
+	}
+	if metadata.CorrelationID == "" {
+		metadata.CorrelationID = metadata.ID
+	}
+	return metadata
+}
+
+// Returns a new random id in the format of a version 4 UUID.
+func NewID() string {
+	id := make([]byte, 16)
+	if _, readErr := rand.Read(id); readErr != nil {
+		panic(fmt.Errorf("cannot generate event id: %w", readErr))
+	}
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+	encoded := hex.EncodeToString(id)
+	return encoded[0:8] + "-" + encoded[8:12] + "-" + encoded[12:16] + "-" + encoded[16:20] + "-" + encoded[20:32]
+}
+
+// Sets all values of the Metadata of the event, for example to add an event received from another system with its
+// original id.
+//
+// Values : The Metadata the event should have
+func WithMetadata(Values Metadata) Option {
+	return func(Metadata *Metadata) { *Metadata = Values }
+}
@@ -0,0 +1,95 @@
+package event
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestCreateEvent_ShouldGenerateMetadata(t *testing.T) {
+	first, second := CreateEvent(1), CreateEvent(2)
+
+	if matched, _ := regexp.MatchString(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, first.ID); !matched {
+		t.Errorf("Expected Field ID To Be A UUID Actual '%s'", first.ID)
+	}
+	if first.ID == second.ID {
+		t.Errorf("Expected Field ID To Be Unique Actual '%s'", first.ID)
+	}
+	if value := first.CorrelationID; value != first.ID {
+		t.Errorf("Expected Field CorrelationID To Equal '%s' Actual '%s'", first.ID, value)
+	}
+	if value := first.CausationID; value != "" {
+		t.Errorf("Expected Field CausationID To Be Empty Actual '%s'", value)
+	}
+}
+
+func TestCreateEvent_Options(t *testing.T) {
+	e := CreateEvent(1,
+		WithID("id"),
+		WithCorrelationID("correlation"),
+		WithCausationID("cause"),
+		WithSource("checkout"),
+		WithHeader("tenant", "a"),
+		WithHeader("user", "b"),
+	)
+
+	expected := Metadata{ID: "id", CorrelationID: "correlation", CausationID: "cause", Source: "checkout"}
+	if value := e.Metadata; value.ID != expected.ID || value.CorrelationID != expected.CorrelationID ||
+		value.CausationID != expected.CausationID || value.Source != expected.Source {
+		t.Errorf("Expected Field Metadata To Equal '%+v' Actual '%+v'", expected, value)
+	}
+	if value := e.Headers.Len(); value != 2 || e.Headers.Get("tenant") != "a" || e.Headers.Get("user") != "b" {
+		t.Errorf("Expected Field Headers To Equal '%v' Actual '%v'", map[string]string{"tenant": "a", "user": "b"}, e.Headers)
+	}
+}
+
+func TestWithCreatedAt(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	e := CreateEventAt(1, time.Unix(0, 0), WithCreatedAt(createdAt))
+	if value := e.CreatedAt(); !value.Equal(createdAt) {
+		t.Errorf("Expected CreatedAt To Equal '%s' Actual '%s'", createdAt, value)
+	}
+	if value := e.TimeStamp; value != createdAt.UnixMilli() {
+		t.Errorf("Expected Field TimeStamp To Equal '%d' Actual '%d'", createdAt.UnixMilli(), value)
+	}
+	if value := CreateEventAt(1, createdAt, WithCreatedAt(time.Time{})).CreatedAt(); !value.Equal(createdAt) {
+		t.Errorf("Expected CreatedAt To Equal '%s' Actual '%s'", createdAt, value)
+	}
+}
+
+func TestCausedBy(t *testing.T) {
+	root := CreateEvent(1)
+	child := CreateEvent(2, CausedBy(root.Metadata))
+	grandChild := CreateEvent(3, CausedBy(child.Metadata))
+
+	if value := child.CausationID; value != root.ID {
+		t.Errorf("Expected child.CausationID To Equal '%s' Actual '%s'", root.ID, value)
+	}
+	if value := grandChild.CausationID; value != child.ID {
+		t.Errorf("Expected grandChild.CausationID To Equal '%s' Actual '%s'", child.ID, value)
+	}
+	if value := grandChild.CorrelationID; value != root.ID {
+		t.Errorf("Expected grandChild.CorrelationID To Equal '%s' Actual '%s'", root.ID, value)
+	}
+
+	orphan := CreateEvent(4, CausedBy(Metadata{ID: "external"}))
+	if value := orphan.CorrelationID; value != "external" {
+		t.Errorf("Expected orphan.CorrelationID To Equal '%s' Actual '%s'", "external", value)
+	}
+}
+
+func TestWithMetadata(t *testing.T) {
+	original := CreateEvent(1, WithSource("billing"), WithHeader("tenant", "a"))
+	copied := CreateEvent(2, WithMetadata(original.Metadata))
+	copied.Headers = copied.Headers.With("tenant", "b")
+
+	if value := copied.ID; value != original.ID {
+		t.Errorf("Expected Field ID To Equal '%s' Actual '%s'", original.ID, value)
+	}
+	if value := copied.Source; value != "billing" {
+		t.Errorf("Expected Field Source To Equal '%s' Actual '%s'", "billing", value)
+	}
+	if value := original.Headers.Get("tenant"); value != "a" {
+		t.Errorf("Expected Original Headers To Be Unchanged Actual '%s'", value)
+	}
+}
@@ -0,0 +1,298 @@
+package fsm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/hijgo/go-bloc/bloc"
+	"github.com/hijgo/go-bloc/event"
+)
+
+// A declarative finite state machine, that can be turned into a BloC.
+// Only the declared transitions are allowed, every other event will be rejected and leaves the state unchanged.
+//
+// S : Type of the states of the machine
+//
+// E : Type of the events of the machine
+//
+// BD : BloCData Type of data that will be available to guards and actions
+type Machine[S comparable, E comparable, BD any] struct {
+	initialState S
+	lock         sync.RWMutex
+	states       []S
+	transitions  []*transition[S, E, BD]
+	onEntry      map[S][]Action[E, BD]
+	onExit       map[S][]Action[E, BD]
+	onRejected   []func(*TransitionError[S, E])
+}
+
+type transition[S comparable, E comparable, BD any] struct {
+	from  S
+	on    E
+	to    S
+	guard Guard[E, BD]
+}
+
+// A function deciding if a transition may be taken.
+//
+// NewEvent : The event that triggered the transition
+//
+// BloCData : The BloCData of the BloC
+type Guard[E any, BD any] func(NewEvent event.Event[E], BloCData *BD) bool
+
+// A function that will be called when a state is entered or exited.
+//
+// NewEvent : The event that triggered the transition
+//
+// BloCData : The BloCData of the BloC, can be changed by the action
+type Action[E any, BD any] func(NewEvent event.Event[E], BloCData *BD)
+
+// Reported when an event is not allowed in the current state of a machine.
+//
+// State : The state the machine was in when the event was rejected
+//
+// Event : The rejected event
+//
+// Guarded : True if a transition for the event exists, but all of its guards rejected the event
+type TransitionError[S comparable, E comparable] struct {
+	State   S
+	Event   E
+	Guarded bool
+}
+
+func (t *TransitionError[S, E]) Error() string {
+	if t.Guarded {
+		return fmt.Sprintf("event '%v' rejected by guard in state '%v'", t.Event, t.State)
+	}
+	return fmt.Sprintf("event '%v' not allowed in state '%v'", t.Event, t.State)
+}
+
+// Function that should be called if a new Machine is needed.
+//
+// S : Type of the states of the machine
+//
+// E : Type of the events of the machine
+//
+// BD : BloCData Type of data that will be available to guards and actions
+//
+// InitialState : The state the machine starts in
+func CreateMachine[S comparable, E comparable, BD any](InitialState S) *Machine[S, E, BD] {
+	m := &Machine[S, E, BD]{
+		initialState: InitialState,
+		onEntry:      make(map[S][]Action[E, BD]),
+		onExit:       make(map[S][]Action[E, BD]),
+	}
+	m.addState(InitialState)
+	return m
+}
+
+// Allows the transition from one state to another state when the given event arrives.
+//
+// From : The state the transition starts at
+//
+// On : The event triggering the transition
+//
+// To : The state the transition ends at
+func (m *Machine[S, E, BD]) Permit(From S, On E, To S) *Machine[S, E, BD] {
+	return m.PermitIf(From, On, To, nil)
+}
+
+// Allows the transition from one state to another state when the given event arrives and the guard returns true.
+// If multiple transitions are declared for the same state and event, the first one whose guard returns true is taken.
+//
+// From : The state the transition starts at
+//
+// On : The event triggering the transition
+//
+// To : The state the transition ends at
+//
+// Guard : Function deciding if the transition may be taken, nil if the transition may always be taken
+func (m *Machine[S, E, BD]) PermitIf(From S, On E, To S, Guard Guard[E, BD]) *Machine[S, E, BD] {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.addState(From)
+	m.addState(To)
+	m.transitions = append(m.transitions, &transition[S, E, BD]{from: From, on: On, to: To, guard: Guard})
+	return m
+}
+
+// Registers an action that will be called whenever the given state is entered through a transition.
+// The action is not called for the initial state.
+//
+// State : The state whose entering should trigger the action
+//
+// EntryAction : The action that should be called
+func (m *Machine[S, E, BD]) OnEntry(State S, EntryAction Action[E, BD]) *Machine[S, E, BD] {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.addState(State)
+	m.onEntry[State] = append(m.onEntry[State], EntryAction)
+	return m
+}
+
+// Registers an action that will be called whenever the given state is left through a transition.
+//
+// State : The state whose leaving should trigger the action
+//
+// ExitAction : The action that should be called
+func (m *Machine[S, E, BD]) OnExit(State S, ExitAction Action[E, BD]) *Machine[S, E, BD] {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.addState(State)
+	m.onExit[State] = append(m.onExit[State], ExitAction)
+	return m
+}
+
+// Registers a function that will be called whenever an event is rejected.
+//
+// OnRejected : Function that will be called with the error describing the rejection
+func (m *Machine[S, E, BD]) OnRejected(OnRejected func(*TransitionError[S, E])) *Machine[S, E, BD] {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.onRejected = append(m.onRejected, OnRejected)
+	return m
+}
+
+// Returns all events that have a declared transition starting at the given state, ignoring guards.
+//
+// State : The state the events should be returned for
+func (m *Machine[S, E, BD]) GetPermittedEvents(State S) []E {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	events := make([]E, 0)
+	seen := make(map[E]bool)
+	for _, t := range m.transitions {
+		if t.from == State && !seen[t.on] {
+			seen[t.on] = true
+			events = append(events, t.on)
+		}
+	}
+	return events
+}
+
+// Returns the state the machine would be in after the event, or a TransitionError if the event is not allowed in the
+// given state. Guards will be called, but no actions.
+//
+// State : The current state
+//
+// NewEvent : The event that should be checked
+//
+// BloCData : The BloCData passed to the guards
+func (m *Machine[S, E, BD]) Fire(State S, NewEvent event.Event[E], BloCData *BD) (S, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	t, rejection := m.find(State, NewEvent, BloCData)
+	if rejection != nil {
+		return State, rejection
+	}
+	return t.to, nil
+}
+
+func (m *Machine[S, E, BD]) find(State S, NewEvent event.Event[E], BloCData *BD) (*transition[S, E, BD], *TransitionError[S, E]) {
+	guarded := false
+	for _, t := range m.transitions {
+		if t.from != State || t.on != NewEvent.Data {
+			continue
+		}
+		if t.guard == nil || t.guard(NewEvent, BloCData) {
+			return t, nil
+		}
+		guarded = true
+	}
+	return nil, &TransitionError[S, E]{State: State, Event: NewEvent.Data, Guarded: guarded}
+}
+
+// Creates a new BloC that starts in the initial state of the machine and only takes the declared transitions.
+// Rejected events leave the state unchanged and will be reported to all functions registered by OnRejected.
+//
+// InitialBloCData : The initial BloCData struct being used by the bloc
+func (m *Machine[S, E, BD]) CreateBloC(InitialBloCData BD) bloc.BloC[E, S, BD] {
+	return bloc.CreateBloCWithState(InitialBloCData, m.initialState, m.mapEventToState)
+}
+
+func (m *Machine[S, E, BD]) mapEventToState(CurrentState S, NewEvent event.Event[E], BloCData *BD) S {
+	m.lock.RLock()
+	t, rejection := m.find(CurrentState, NewEvent, BloCData)
+	onRejected := m.onRejected
+	var exitActions, entryActions []Action[E, BD]
+	if rejection == nil {
+		exitActions, entryActions = m.onExit[t.from], m.onEntry[t.to]
+	}
+	m.lock.RUnlock()
+
+	if rejection != nil {
+		for _, report := range onRejected {
+			report(rejection)
+		}
+		return CurrentState
+	}
+
+	for _, action := range exitActions {
+		action(NewEvent, BloCData)
+	}
+	for _, action := range entryActions {
+		action(NewEvent, BloCData)
+	}
+	return t.to
+}
+
+func (m *Machine[S, E, BD]) addState(State S) {
+	for _, existing := range m.states {
+		if existing == State {
+			return
+		}
+	}
+	m.states = append(m.states, State)
+}
+
+// Returns the machine as a diagram in the Graphviz DOT language.
+func (m *Machine[S, E, BD]) ToDOT() string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	var builder strings.Builder
+	builder.WriteString("digraph fsm {\n")
+	builder.WriteString("  rankdir=LR;\n")
+	builder.WriteString("  \"__start\" [shape=point];\n")
+	for _, state := range m.states {
+		fmt.Fprintf(&builder, "  %q;\n", fmt.Sprint(state))
+	}
+	fmt.Fprintf(&builder, "  \"__start\" -> %q;\n", fmt.Sprint(m.initialState))
+	for _, t := range m.transitions {
+		fmt.Fprintf(&builder, "  %q -> %q [label=%q];\n", fmt.Sprint(t.from), fmt.Sprint(t.to), transitionLabel(t))
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// Returns the machine as a Mermaid state diagram.
+func (m *Machine[S, E, BD]) ToMermaid() string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	var builder strings.Builder
+	builder.WriteString("stateDiagram-v2\n")
+	for _, state := range m.states {
+		fmt.Fprintf(&builder, "    state \"%s\" as %s\n", fmt.Sprint(state), mermaidID(state))
+	}
+	fmt.Fprintf(&builder, "    [*] --> %s\n", mermaidID(m.initialState))
+	for _, t := range m.transitions {
+		fmt.Fprintf(&builder, "    %s --> %s : %s\n", mermaidID(t.from), mermaidID(t.to), transitionLabel(t))
+	}
+	return builder.String()
+}
+
+var mermaidInvalidCharacters = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+func mermaidID(State any) string {
+	return "s_" + mermaidInvalidCharacters.ReplaceAllString(fmt.Sprint(State), "_")
+}
+
+func transitionLabel[S comparable, E comparable, BD any](t *transition[S, E, BD]) string {
+	if t.guard != nil {
+		return fmt.Sprintf("%v [guarded]", t.on)
+	}
+	return fmt.Sprint(t.on)
+}
@@ -0,0 +1,151 @@
+package fsm
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hijgo/go-bloc/bloc"
+	"github.com/hijgo/go-bloc/event"
+)
+
+type OrderState string
+
+type OrderEvent string
+
+const (
+	Pending   OrderState = "Pending"
+	Paid      OrderState = "Paid"
+	Shipped   OrderState = "Shipped"
+	Cancelled OrderState = "Cancelled"
+
+	Pay    OrderEvent = "Pay"
+	Ship   OrderEvent = "Ship"
+	Cancel OrderEvent = "Cancel"
+)
+
+type BD struct {
+	Balance int
+	Log     []string
+}
+
+func createOrderMachine() *Machine[OrderState, OrderEvent, BD] {
+	return CreateMachine[OrderState, OrderEvent, BD](Pending).
+		PermitIf(Pending, Pay, Paid, func(NewEvent event.Event[OrderEvent], BloCData *BD) bool { return BloCData.Balance >= 10 }).
+		Permit(Pending, Cancel, Cancelled).
+		Permit(Paid, Ship, Shipped).
+		OnExit(Pending, func(NewEvent event.Event[OrderEvent], BloCData *BD) {
+			BloCData.Log = append(BloCData.Log, "exit Pending")
+		}).
+		OnEntry(Paid, func(NewEvent event.Event[OrderEvent], BloCData *BD) {
+			BloCData.Balance -= 10
+			BloCData.Log = append(BloCData.Log, "enter Paid")
+		})
+}
+
+func TestMachine_Fire(t *testing.T) {
+	m := createOrderMachine()
+
+	state, err := m.Fire(Pending, event.CreateEvent(Cancel), &BD{})
+	if err != nil || state != Cancelled {
+		t.Errorf("Expected Fire To Equal '%s' Actual '%s' '%v'", Cancelled, state, err)
+	}
+
+	_, err = m.Fire(Pending, event.CreateEvent(Ship), &BD{})
+	var transitionErr *TransitionError[OrderState, OrderEvent]
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("Expected Fire To Return TransitionError Actual '%v'", err)
+	}
+	if transitionErr.State != Pending || transitionErr.Event != Ship || transitionErr.Guarded {
+		t.Errorf("Expected TransitionError To Describe Rejection Actual '%+v'", *transitionErr)
+	}
+
+	_, err = m.Fire(Pending, event.CreateEvent(Pay), &BD{Balance: 5})
+	if !errors.As(err, &transitionErr) || !transitionErr.Guarded {
+		t.Errorf("Expected Fire To Return Guarded TransitionError Actual '%v'", err)
+	}
+}
+
+func TestMachine_GetPermittedEvents(t *testing.T) {
+	m := createOrderMachine()
+
+	events := m.GetPermittedEvents(Pending)
+	if value := len(events); value != 2 || events[0] != Pay || events[1] != Cancel {
+		t.Errorf("Expected GetPermittedEvents To Equal '%v' Actual '%v'", []OrderEvent{Pay, Cancel}, events)
+	}
+}
+
+func TestMachine_CreateBloC(t *testing.T) {
+	var wg sync.WaitGroup
+	rejections := make([]*TransitionError[OrderState, OrderEvent], 0)
+	m := createOrderMachine().OnRejected(func(Rejection *TransitionError[OrderState, OrderEvent]) {
+		rejections = append(rejections, Rejection)
+	})
+	b := m.CreateBloC(BD{Balance: 15})
+	defer b.Dispose()
+	b.AddTransitionListener(func(bloc.Transition[OrderEvent, OrderState, BD]) { wg.Done() })
+
+	if state, _ := b.GetState(); state != Pending {
+		t.Errorf("Expected GetState To Equal '%s' Actual '%s'", Pending, state)
+	}
+	if err := b.StartListenToEventStream(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	wg.Add(3)
+	if err := b.AddEvent(Ship); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	if err := b.AddEvent(Pay); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	if err := b.AddEvent(Ship); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	if state, _ := b.GetState(); state != Shipped {
+		t.Errorf("Expected GetState To Equal '%s' Actual '%s'", Shipped, state)
+	}
+	if value := len(rejections); value != 1 || rejections[0].Event != Ship {
+		t.Errorf("Expected One Rejection Of '%s' Actual '%v'", Ship, rejections)
+	}
+	data := b.GetBloCData()
+	if data.Balance != 5 {
+		t.Errorf("Expected Balance To Equal '%d' Actual '%d'", 5, data.Balance)
+	}
+	if value := strings.Join(data.Log, ","); value != "exit Pending,enter Paid" {
+		t.Errorf("Expected Log To Equal '%s' Actual '%s'", "exit Pending,enter Paid", value)
+	}
+}
+
+func TestMachine_ToDOT(t *testing.T) {
+	dot := createOrderMachine().ToDOT()
+
+	for _, expected := range []string{
+		"digraph fsm {",
+		`"__start" -> "Pending";`,
+		`"Pending" -> "Paid" [label="Pay [guarded]"];`,
+		`"Paid" -> "Shipped" [label="Ship"];`,
+	} {
+		if !strings.Contains(dot, expected) {
+			t.Errorf("Expected ToDOT To Contain '%s' Actual '%s'", expected, dot)
+		}
+	}
+}
+
+func TestMachine_ToMermaid(t *testing.T) {
+	mermaid := createOrderMachine().ToMermaid()
+
+	for _, expected := range []string{
+		"stateDiagram-v2",
+		"[*] --> s_Pending",
+		"s_Pending --> s_Cancelled : Cancel",
+		`state "Shipped" as s_Shipped`,
+	} {
+		if !strings.Contains(mermaid, expected) {
+			t.Errorf("Expected ToMermaid To Contain '%s' Actual '%s'", expected, mermaid)
+		}
+	}
+}
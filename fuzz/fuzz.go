@@ -0,0 +1,283 @@
+package fuzz
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/event"
+)
+
+// Default maximum number of events generated from a single fuzz input.
+var DefaultMaxEvents = 64
+
+// The time the first event of every sequence is created at, every further event is created one second later. Together
+// with ids derived from the position of an event, mappers reading the metadata of events behave the same in every run.
+var startTime = time.Unix(0, 0).UTC()
+
+// Checks a property that must hold for every state and BloCData produced by a mapper.
+// Returns an error describing the violation, or nil if the property holds.
+//
+// S : Type of the states produced by the mapper
+//
+// BD : BloCData Type of the data available to the mapper
+type Invariant[S any, BD any] func(State S, BloCData BD) error
+
+// Returns an Invariant that fails with the given name, whenever Holds returns false.
+//
+// Name : Description of the property, for example "balance never negative"
+//
+// Holds : Function returning true if the property holds
+func Check[S any, BD any](Name string, Holds func(State S, BloCData BD) bool) Invariant[S, BD] {
+	return func(State S, BloCData BD) error {
+		if !Holds(State, BloCData) {
+			return fmt.Errorf("invariant '%s' violated", Name)
+		}
+		return nil
+	}
+}
+
+// Runs generated sequences of events through a mapper and checks the invariants after every event.
+// The mapper is called directly, so neither streams nor goroutines are involved. The id and time of creation of an
+// event only depend on its position in the sequence, so every sequence can be reproduced.
+//
+// E : Type of events being mapped
+//
+// S : Type of states being produced by the mapper
+//
+// BD : BloCData Type of data that will be available to the mapper
+//
+// InitialState : The state every sequence starts with
+//
+// InitialBloCData : The BloCData every sequence starts with. Only copied shallowly for every sequence, so if BD holds
+// maps, slices or pointers, use CreateBloCData instead, otherwise changes made by the mapper carry over to later runs
+//
+// CreateBloCData : Function returning a new BloCData every sequence starts with, used instead of InitialBloCData if set
+//
+// MapEventToState : The mapper under test, use the function passed to bloc.CreateBloCWithState or wrap the function
+// passed to bloc.CreateBloC
+//
+// Events : The events sequences are built from, every byte of the fuzz input selects one event. Ignored if Generate
+// is set
+//
+// Generate : Function decoding a single event from the fuzz input, may be nil
+//
+// Invariants : The properties checked for the initial state and after every event
+//
+// MaxEvents : The maximum length of a generated sequence, DefaultMaxEvents if zero
+type Harness[E any, S any, BD any] struct {
+	InitialState    S
+	InitialBloCData BD
+	CreateBloCData  func() BD
+	MapEventToState func(CurrentState S, NewEvent event.Event[E], BloCData *BD) S
+	Events          []E
+	Generate        func(Input *Source) E
+	Invariants      []Invariant[S, BD]
+	MaxEvents       int
+}
+
+// Describes a sequence of events for which an invariant did not hold.
+//
+// Events : The sequence of events, starting from the initial state and BloCData
+//
+// Step : The number of events that were mapped before the violation was detected, 0 for the initial state
+//
+// State : The state the invariant did not hold for
+//
+// BloCData : The BloCData the invariant did not hold for
+//
+// Err : The error returned by the invariant, or describing a panic of the mapper
+type Violation[E any, S any, BD any] struct {
+	Events   []E
+	Step     int
+	State    S
+	BloCData BD
+	Err      error
+}
+
+func (v *Violation[E, S, BD]) Error() string {
+	return fmt.Sprintf("%s after %d of %d events, state '%+v', BloCData '%+v'", v.Err.Error(), v.Step, len(v.Events), v.State, v.BloCData)
+}
+
+func (v *Violation[E, S, BD]) Unwrap() error {
+	return v.Err
+}
+
+// Maps the sequence of events and checks the invariants after every event.
+//
+// Events : The sequence of events that should be mapped
+//
+// Will return a *Violation if an invariant did not hold or the mapper panicked.
+func (h *Harness[E, S, BD]) Run(Events []E) error {
+	if violation := h.run(Events); violation != nil {
+		return violation
+	}
+	return nil
+}
+
+func (h *Harness[E, S, BD]) run(Events []E) (violation *Violation[E, S, BD]) {
+	state, bloCData := h.InitialState, h.InitialBloCData
+	if h.CreateBloCData != nil {
+		bloCData = h.CreateBloCData()
+	}
+	step := 0
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			violation = &Violation[E, S, BD]{Events: Events, Step: step, State: state, BloCData: bloCData, Err: fmt.Errorf("mapper panicked: %v", recovered)}
+		}
+	}()
+
+	if checkErr := h.check(state, bloCData); checkErr != nil {
+		return &Violation[E, S, BD]{Events: Events, Step: 0, State: state, BloCData: bloCData, Err: checkErr}
+	}
+	for i, newEvent := range Events {
+		step = i + 1
+		state = h.MapEventToState(state, createEvent(newEvent, i), &bloCData)
+		if checkErr := h.check(state, bloCData); checkErr != nil {
+			return &Violation[E, S, BD]{Events: Events, Step: step, State: state, BloCData: bloCData, Err: checkErr}
+		}
+	}
+	return nil
+}
+
+// Creates the event at the given position of a sequence, its id and time of creation only depend on the position.
+func createEvent[E any](Data E, Position int) event.Event[E] {
+	return event.CreateEventAt(Data, startTime.Add(time.Duration(Position)*time.Second), event.WithID(strconv.Itoa(Position)))
+}
+
+func (h *Harness[E, S, BD]) check(State S, BloCData BD) error {
+	for _, invariant := range h.Invariants {
+		if checkErr := invariant(State, BloCData); checkErr != nil {
+			return checkErr
+		}
+	}
+	return nil
+}
+
+// Returns the shortest sequence of events found, that still violates an invariant. The sequence is first cut after
+// the violating event, then single events and chunks of events are removed as long as the violation remains.
+//
+// Events : A sequence of events violating an invariant
+//
+// Will return the sequence unchanged if it does not violate any invariant.
+func (h *Harness[E, S, BD]) Minimize(Events []E) []E {
+	violation := h.run(Events)
+	if violation == nil {
+		return Events
+	}
+	current := append([]E(nil), Events[:violation.Step]...)
+
+	for chunk := len(current) / 2; chunk >= 1; chunk /= 2 {
+		for start := 0; start+chunk <= len(current); {
+			candidate := append(append([]E(nil), current[:start]...), current[start+chunk:]...)
+			if h.run(candidate) != nil {
+				current = candidate
+				continue
+			}
+			start++
+		}
+	}
+	return current
+}
+
+// Decodes a sequence of events from the fuzz input.
+//
+// Input : The raw fuzz input
+func (h *Harness[E, S, BD]) Decode(Input []byte) []E {
+	maxEvents := h.MaxEvents
+	if maxEvents <= 0 {
+		maxEvents = DefaultMaxEvents
+	}
+	events := make([]E, 0)
+	source := &Source{data: Input}
+	for !source.Exhausted() && len(events) < maxEvents {
+		if h.Generate != nil {
+			events = append(events, h.Generate(source))
+			continue
+		}
+		if len(h.Events) == 0 {
+			break
+		}
+		events = append(events, h.Events[source.Intn(len(h.Events))])
+	}
+	return events
+}
+
+// Runs the harness with Go's native fuzzing, call it from a FuzzXxx function. On a violation the sequence is
+// minimized and reported as Go literal, so it can be turned into a regular test case calling Run.
+//
+// f : The fuzz target
+//
+// Seeds : Sequences of events added to the seed corpus
+func (h *Harness[E, S, BD]) Fuzz(f *testing.F, Seeds ...[]E) {
+	f.Helper()
+	for _, seed := range Seeds {
+		f.Add(h.encode(seed))
+	}
+	f.Add([]byte{})
+	f.Fuzz(func(t *testing.T, Input []byte) {
+		events := h.Decode(Input)
+		if h.run(events) == nil {
+			return
+		}
+		minimized := h.Minimize(events)
+		t.Fatalf("%s\nreproduce with: Run(%#v)", h.run(minimized).Error(), minimized)
+	})
+}
+
+// Encodes a sequence of events as fuzz input, only possible for events out of Events.
+func (h *Harness[E, S, BD]) encode(Events []E) []byte {
+	input := make([]byte, 0, len(Events))
+	for _, newEvent := range Events {
+		for i, candidate := range h.Events {
+			if reflect.DeepEqual(candidate, newEvent) {
+				input = append(input, byte(i))
+				break
+			}
+		}
+	}
+	return input
+}
+
+// The fuzz input, consumed by Harness.Generate to build events. Reading past the end of the input returns zero values.
+type Source struct {
+	data     []byte
+	position int
+}
+
+// Returns true if the whole input was consumed.
+func (s *Source) Exhausted() bool {
+	return s.position >= len(s.data)
+}
+
+// Returns the next byte of the input.
+func (s *Source) Byte() byte {
+	if s.Exhausted() {
+		return 0
+	}
+	b := s.data[s.position]
+	s.position++
+	return b
+}
+
+// Returns true or false, depending on the next byte of the input.
+func (s *Source) Bool() bool {
+	return s.Byte()&1 == 1
+}
+
+// Returns a number in [0, N), depending on the next byte of the input, N must be between 1 and 256.
+func (s *Source) Intn(N int) int {
+	return int(s.Byte()) % N
+}
+
+// Returns a number decoded from the next eight bytes of the input.
+func (s *Source) Int64() int64 {
+	buffer := make([]byte, 8)
+	for i := range buffer {
+		buffer[i] = s.Byte()
+	}
+	return int64(binary.LittleEndian.Uint64(buffer))
+}
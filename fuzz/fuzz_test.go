@@ -0,0 +1,142 @@
+package fuzz
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hijgo/go-bloc/event"
+)
+
+type Account struct {
+	Balance int
+}
+
+type Transactions struct {
+	Count int
+}
+
+func account(AllowOverdraft bool) *Harness[int, Account, Transactions] {
+	return &Harness[int, Account, Transactions]{
+		MapEventToState: func(CurrentState Account, NewEvent event.Event[int], BloCData *Transactions) Account {
+			if !AllowOverdraft && CurrentState.Balance+NewEvent.Data < 0 {
+				return CurrentState
+			}
+			BloCData.Count++
+			return Account{Balance: CurrentState.Balance + NewEvent.Data}
+		},
+		Events: []int{10, -5, -20, 1},
+		Invariants: []Invariant[Account, Transactions]{
+			Check("balance never negative", func(State Account, _ Transactions) bool { return State.Balance >= 0 }),
+		},
+	}
+}
+
+func FuzzHarness(f *testing.F) {
+	account(false).Fuzz(f, []int{10, -5, -5}, []int{-20, 1, 10})
+}
+
+func TestHarness_Run(t *testing.T) {
+	if err := account(false).Run([]int{-20, 10, -5, -20}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+
+	err := account(true).Run([]int{10, 1, -5, -20, 10})
+	var violation *Violation[int, Account, Transactions]
+	if !errors.As(err, &violation) {
+		t.Fatalf("Expected Violation Actual '%v'", err)
+	}
+	if value := violation.Step; value != 4 {
+		t.Errorf("Expected Step To Be Of Value '%d' Actual '%d'", 4, value)
+	}
+	if value := violation.State.Balance; value != -14 {
+		t.Errorf("Expected State.Balance To Be Of Value '%d' Actual '%d'", -14, value)
+	}
+	if value := violation.BloCData.Count; value != 4 {
+		t.Errorf("Expected BloCData.Count To Be Of Value '%d' Actual '%d'", 4, value)
+	}
+}
+
+func TestHarness_RunShouldReportPanics(t *testing.T) {
+	h := account(false)
+	h.MapEventToState = func(CurrentState Account, NewEvent event.Event[int], _ *Transactions) Account {
+		if NewEvent.Data == 1 {
+			panic("unexpected event")
+		}
+		return CurrentState
+	}
+	var violation *Violation[int, Account, Transactions]
+	if err := h.Run([]int{10, 1}); !errors.As(err, &violation) || violation.Step != 2 {
+		t.Errorf("Expected Violation At Step '%d' Actual '%v'", 2, err)
+	}
+}
+
+func TestHarness_RunShouldBeReproducible(t *testing.T) {
+	h := account(true)
+	h.MapEventToState = func(CurrentState Account, NewEvent event.Event[int], _ *Transactions) Account {
+		return Account{Balance: CurrentState.Balance + int(NewEvent.Time.Unix()) - len(NewEvent.ID)}
+	}
+	first := h.Run([]int{0, 0, 0})
+	if first == nil {
+		t.Fatalf("Expected Violation Actual '%v'", first)
+	}
+	for i := 0; i < 10; i++ {
+		if value := h.Run([]int{0, 0, 0}); value == nil || value.Error() != first.Error() {
+			t.Errorf("Expected Run To Return '%v' Actual '%v'", first, value)
+		}
+	}
+	if minimized := h.Minimize([]int{0, 0, 0}); !reflect.DeepEqual(minimized, []int{0}) {
+		t.Errorf("Expected Minimize To Return '%v' Actual '%v'", []int{0}, minimized)
+	}
+}
+
+func TestHarness_CreateBloCData(t *testing.T) {
+	h := &Harness[int, Account, map[int]bool]{
+		CreateBloCData: func() map[int]bool { return make(map[int]bool) },
+		MapEventToState: func(CurrentState Account, NewEvent event.Event[int], BloCData *map[int]bool) Account {
+			(*BloCData)[NewEvent.Data] = true
+			return CurrentState
+		},
+		Invariants: []Invariant[Account, map[int]bool]{
+			Check("at most two events", func(_ Account, BloCData map[int]bool) bool { return len(BloCData) <= 2 }),
+		},
+	}
+	for _, events := range [][]int{{1, 2}, {3, 4}, {5}} {
+		if err := h.Run(events); err != nil {
+			t.Errorf("Unexpected error occured: %s", err.Error())
+		}
+	}
+}
+
+func TestHarness_Minimize(t *testing.T) {
+	minimized := account(true).Minimize([]int{10, 1, 10, -5, -20, 1, 10, -20})
+	if expected := []int{-20}; !reflect.DeepEqual(minimized, expected) {
+		t.Errorf("Expected Minimize To Return '%v' Actual '%v'", expected, minimized)
+	}
+
+	valid := []int{10, -5}
+	if minimized := account(true).Minimize(valid); !reflect.DeepEqual(minimized, valid) {
+		t.Errorf("Expected Minimize To Return '%v' Actual '%v'", valid, minimized)
+	}
+}
+
+func TestHarness_Decode(t *testing.T) {
+	h := account(false)
+	if value := h.Decode([]byte{0, 1, 6, 3}); !reflect.DeepEqual(value, []int{10, -5, -20, 1}) {
+		t.Errorf("Expected Decode To Return '%v' Actual '%v'", []int{10, -5, -20, 1}, value)
+	}
+	if value := h.encode([]int{1, 10}); !reflect.DeepEqual(value, []byte{3, 0}) {
+		t.Errorf("Expected encode To Return '%v' Actual '%v'", []byte{3, 0}, value)
+	}
+
+	h.MaxEvents = 2
+	h.Generate = func(Input *Source) int {
+		if Input.Bool() {
+			return -int(Input.Byte())
+		}
+		return int(Input.Int64() % 100)
+	}
+	if value := h.Decode([]byte{1, 7, 0, 42, 0, 0, 0, 0, 0, 0, 0, 9}); !reflect.DeepEqual(value, []int{-7, 42}) {
+		t.Errorf("Expected Decode To Return '%v' Actual '%v'", []int{-7, 42}, value)
+	}
+}
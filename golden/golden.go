@@ -0,0 +1,268 @@
+package golden
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/bloc"
+	"github.com/hijgo/go-bloc/clock"
+	err "github.com/hijgo/go-bloc/error"
+	"github.com/hijgo/go-bloc/event"
+)
+
+// Makes Assert rewrite golden files with the states produced by the current mapEventToState instead of comparing them,
+// when running `go test -update`. Read by Assert, after the test binary parsed its flags.
+var update = flag.Bool("update", false, "rewrite golden files of BloC sessions")
+
+// Name of the environment variable that can be used instead of -update, for example when running
+// `GOLDEN_UPDATE=1 go test ./...` for packages that do not import golden.
+const UpdateEnv = "GOLDEN_UPDATE"
+
+// Returns true if -update was passed or the environment variable UpdateEnv is set to a true value like 1 or true.
+func shouldUpdate() bool {
+	if *update {
+		return true
+	}
+	value, parseErr := strconv.ParseBool(os.Getenv(UpdateEnv))
+	return parseErr == nil && value
+}
+
+// A recorded session of a BloC, the events that were added and the states they produced.
+//
+// E : Type of events being emitted into the BloC
+//
+// S : Type of states being produced by the BloC from incoming events
+//
+// Entries : Every event added during the session, ordered from the oldest to the newest event
+type Recording[E any, S any] struct {
+	Entries []Entry[E, S] `json:"entries"`
+}
+
+// A single event of a recorded session.
+//
+// ID : The id of the event, replayed events keep their id so deduplication by id is reproduced
+//
+// Event : The data of the event that was added
+//
+// State : The state the event was mapped to, the zero value of S if the event caused no transition
+//
+// NoTransition : True if the event caused no transition, because it was rejected or dropped by a middleware, the state
+// was vetoed by an interceptor or equals the previous state
+type Entry[E any, S any] struct {
+	ID           string `json:"id,omitempty"`
+	Event        E      `json:"event"`
+	State        S      `json:"state"`
+	NoTransition bool   `json:"noTransition,omitempty"`
+}
+
+// Records every event added to a BloC and the transition it caused, until Stop is called.
+type Recorder[E any, S any, BD any] struct {
+	lock            sync.Mutex
+	recording       Recording[E, S]
+	pending         map[string][]int
+	removeListeners []func()
+}
+
+// Function that should be called to start recording a session of a BloC.
+// Every added event is recorded, events that cause no transition are marked by NoTransition.
+//
+// BloC : The BloC whose events and transitions should be recorded
+func Record[E any, S any, BD any](BloC *bloc.BloC[E, S, BD]) *Recorder[E, S, BD] {
+	r := &Recorder[E, S, BD]{recording: Recording[E, S]{Entries: make([]Entry[E, S], 0)}, pending: make(map[string][]int)}
+	r.removeListeners = []func(){
+		BloC.AddDispatchListener(func(NewEvent event.Event[E]) {
+			r.lock.Lock()
+			defer r.lock.Unlock()
+			r.pending[NewEvent.ID] = append(r.pending[NewEvent.ID], len(r.recording.Entries))
+			r.recording.Entries = append(r.recording.Entries, Entry[E, S]{ID: NewEvent.ID, Event: NewEvent.Data, NoTransition: true})
+		}),
+		BloC.AddTransitionListener(func(Transition bloc.Transition[E, S, BD]) {
+			r.lock.Lock()
+			defer r.lock.Unlock()
+			indexes := r.pending[Transition.Event.ID]
+			if len(indexes) == 0 {
+				return
+			}
+			r.pending[Transition.Event.ID] = indexes[1:]
+			if len(indexes) == 1 {
+				delete(r.pending, Transition.Event.ID)
+			}
+			entry := &r.recording.Entries[indexes[0]]
+			entry.State, entry.NoTransition = Transition.NextState, false
+		}),
+	}
+	return r
+}
+
+// Stops recording new events and transitions.
+func (r *Recorder[E, S, BD]) Stop() {
+	for _, removeListener := range r.removeListeners {
+		removeListener()
+	}
+}
+
+// Returns a copy of the session recorded so far.
+func (r *Recorder[E, S, BD]) GetRecording() Recording[E, S] {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	entries := make([]Entry[E, S], len(r.recording.Entries))
+	copy(entries, r.recording.Entries)
+	return Recording[E, S]{Entries: entries}
+}
+
+// Writes the session recorded so far to the file at the given path, creating missing directories.
+//
+// Path : The path of the golden file
+func (r *Recorder[E, S, BD]) Save(Path string) error {
+	return Save(Path, r.GetRecording())
+}
+
+// Writes the recording as JSON to the file at the given path, creating missing directories.
+//
+// Path : The path of the golden file
+//
+// Session : The recording that should be written
+func Save[E any, S any](Path string, Session Recording[E, S]) error {
+	data, encodeErr := json.MarshalIndent(Session, "", "  ")
+	if encodeErr != nil {
+		return &err.Error{Context: "Cannot encode recording!", Err: encodeErr}
+	}
+	if mkdirErr := os.MkdirAll(filepath.Dir(Path), 0o755); mkdirErr != nil {
+		return &err.Error{Context: "Cannot create directory of golden file!", Err: mkdirErr}
+	}
+	if writeErr := os.WriteFile(Path, append(data, '\n'), 0o644); writeErr != nil {
+		return &err.Error{Context: "Cannot write golden file!", Err: writeErr}
+	}
+	return nil
+}
+
+// Reads a recording from the file at the given path.
+//
+// Path : The path of the golden file
+func Load[E any, S any](Path string) (Recording[E, S], error) {
+	var recording Recording[E, S]
+	data, readErr := os.ReadFile(Path)
+	if readErr != nil {
+		return recording, &err.Error{Context: "Cannot read golden file!", Err: readErr}
+	}
+	if decodeErr := json.Unmarshal(data, &recording); decodeErr != nil {
+		return recording, &err.Error{Context: "Cannot decode golden file!", Err: decodeErr}
+	}
+	return recording, nil
+}
+
+// Adds the events of the recording to a new BloC and returns the session produced by it.
+// The BloC is driven by a clock.Virtual, so no goroutines are involved in mapping the events.
+//
+// Build : Function creating a new BloC, using the current mapEventToState. The BloC must not be listened to yet
+//
+// Session : The recording whose events should be replayed
+//
+// Events rejected by a middleware are recorded as NoTransition, like during the recorded session. Entries without an
+// id get a new id when replayed, which is not part of the returned session, so rewritten golden files stay stable.
+//
+// Will return an error if the BloC could not be listened to.
+func Replay[E any, S any, BD any](Build func() bloc.BloC[E, S, BD], Session Recording[E, S]) (Recording[E, S], error) {
+	b := Build()
+	defer b.Dispose()
+
+	virtual := clock.CreateVirtualClock(time.Unix(0, 0))
+	b.SetClock(virtual)
+	if schedulerErr := b.SetScheduler(virtual); schedulerErr != nil {
+		return Recording[E, S]{}, schedulerErr
+	}
+	recorder := Record(&b)
+	if listenErr := b.StartListenToEventStream(); listenErr != nil {
+		return Recording[E, S]{}, listenErr
+	}
+	for _, entry := range Session.Entries {
+		options := make([]event.Option, 0, 1)
+		if entry.ID != "" {
+			options = append(options, event.WithID(entry.ID))
+		}
+		_ = b.AddEvent(entry.Event, options...)
+		virtual.Flush()
+	}
+	replayed := recorder.GetRecording()
+	for i := range replayed.Entries {
+		if i < len(Session.Entries) && Session.Entries[i].ID == "" {
+			replayed.Entries[i].ID = ""
+		}
+	}
+	return replayed, nil
+}
+
+// Replays the events of the golden file against a new BloC and fails if the produced states diverge from the golden
+// file. When running `go test -update` or with the environment variable UpdateEnv set, the golden file will be
+// rewritten with the produced states instead.
+//
+// t : Receives the failures, usually a *testing.T
+//
+// Path : The path of the golden file, usually inside the testdata directory
+//
+// Build : Function creating a new BloC, using the current mapEventToState. The BloC must not be listened to yet
+func Assert[E any, S any, BD any](t testing.TB, Path string, Build func() bloc.BloC[E, S, BD]) {
+	t.Helper()
+	expected, loadErr := Load[E, S](Path)
+	if loadErr != nil {
+		t.Fatalf("Unexpected error occured: %s", loadErr.Error())
+		return
+	}
+
+	actual, replayErr := Replay(Build, expected)
+	if replayErr != nil {
+		t.Errorf("Unexpected error occured: %s", replayErr.Error())
+	}
+
+	if shouldUpdate() {
+		if saveErr := Save(Path, actual); saveErr != nil {
+			t.Errorf("Unexpected error occured: %s", saveErr.Error())
+		}
+		return
+	}
+
+	if diff := diff(expected, actual); diff != "" {
+		t.Errorf("Expected States To Equal Golden File '%s', run with -update to rewrite it\n%s", Path, diff)
+	}
+}
+
+func diff[E any, S any](Expected Recording[E, S], Actual Recording[E, S]) string {
+	for i := range Expected.Entries {
+		expectedState := describe(Expected.Entries[i])
+		if i >= len(Actual.Entries) {
+			return fmt.Sprintf("  event [%d] %s\n  - %s\n  + not replayed", i, encode(Expected.Entries[i].Event), expectedState)
+		}
+		actualState := describe(Actual.Entries[i])
+		if expectedState != actualState {
+			return fmt.Sprintf("  event [%d] %s\n  - %s\n  + %s", i, encode(Expected.Entries[i].Event), expectedState, actualState)
+		}
+	}
+	if len(Actual.Entries) > len(Expected.Entries) {
+		extra := Actual.Entries[len(Expected.Entries)]
+		return fmt.Sprintf("  event [%d] %s\n  - not recorded\n  + %s", len(Expected.Entries), encode(extra.Event), describe(extra))
+	}
+	return ""
+}
+
+// Returns the outcome of the entry as shown in a diff.
+func describe[E any, S any](Entry Entry[E, S]) string {
+	if Entry.NoTransition {
+		return "no transition"
+	}
+	return fmt.Sprintf("state %s", encode(Entry.State))
+}
+
+func encode(Value any) []byte {
+	data, encodeErr := json.Marshal(Value)
+	if encodeErr != nil {
+		return []byte(fmt.Sprintf("%+v", Value))
+	}
+	return data
+}
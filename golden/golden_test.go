@@ -0,0 +1,199 @@
+package golden
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/bloc"
+	"github.com/hijgo/go-bloc/clock"
+	"github.com/hijgo/go-bloc/event"
+)
+
+type Counter struct {
+	Count int `json:"count"`
+}
+
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(Format string, Args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(Format, Args...))
+}
+
+func (r *recorder) Fatalf(Format string, Args ...any) {
+	r.Errorf(Format, Args...)
+}
+
+func buildCounter(Factor int) func() bloc.BloC[int, Counter, struct{}] {
+	return func() bloc.BloC[int, Counter, struct{}] {
+		return bloc.CreateBloCWithState(struct{}{}, Counter{}, func(CurrentState Counter, NewEvent event.Event[int], _ *struct{}) Counter {
+			return Counter{Count: CurrentState.Count + Factor*NewEvent.Data}
+		})
+	}
+}
+
+func TestAssert(t *testing.T) {
+	Assert(t, filepath.Join("testdata", "counter.golden.json"), buildCounter(1))
+}
+
+func TestRecord(t *testing.T) {
+	var wg sync.WaitGroup
+	b := buildCounter(1)()
+	defer b.Dispose()
+	r := Record(&b)
+	b.AddTransitionListener(func(bloc.Transition[int, Counter, struct{}]) { wg.Done() })
+	if err := b.StartListenToEventStream(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	wg.Add(3)
+	for _, newEvent := range []int{1, 2, 3} {
+		if err := b.AddEvent(newEvent); err != nil {
+			t.Errorf("Unexpected error occured: %s", err.Error())
+		}
+	}
+	wg.Wait()
+	r.Stop()
+
+	path := filepath.Join(t.TempDir(), "nested", "session.json")
+	if err := r.Save(path); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	loaded, err := Load[int, Counter](path)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if value := len(loaded.Entries); value != 3 {
+		t.Fatalf("Expected len(Entries) To Be Of Value '%d' Actual '%d'", 3, value)
+	}
+	if value := loaded.Entries[2]; value.Event != 3 || value.State.Count != 6 {
+		t.Errorf("Expected Entries[2] To Equal '%+v' Actual '%+v'", Entry[int, Counter]{Event: 3, State: Counter{Count: 6}}, value)
+	}
+
+	Assert(t, path, buildCounter(1))
+}
+
+func buildGuardedCounter() bloc.BloC[int, Counter, struct{}] {
+	b := buildCounter(1)()
+	b.UseEventMiddleware(func(NewEvent event.Event[int], Next bloc.EventHandler[int]) error {
+		if NewEvent.Data < 0 {
+			return errors.New("negative events are not allowed")
+		}
+		return Next(NewEvent)
+	})
+	b.UseDeduplication(bloc.CreateMemoryDedupeStore(0, time.Minute), nil)
+	b.DeduplicateStates(nil)
+	return b
+}
+
+func TestRecord_ShouldRecordEventsWithoutTransition(t *testing.T) {
+	v := clock.CreateVirtualClock(time.Unix(0, 0))
+	b := buildGuardedCounter()
+	defer b.Dispose()
+	if err := b.SetScheduler(v); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	r := Record(&b)
+	if err := b.StartListenToEventStream(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	_ = b.AddEvent(1, event.WithID("first"))
+	_ = b.AddEvent(-1)
+	_ = b.AddEvent(1, event.WithID("first"))
+	_ = b.AddEvent(0)
+	_ = b.AddEvent(2)
+	v.Flush()
+	r.Stop()
+
+	session := r.GetRecording()
+	expected := []Entry[int, Counter]{
+		{ID: "first", Event: 1, State: Counter{Count: 1}},
+		{Event: -1, NoTransition: true},
+		{ID: "first", Event: 1, NoTransition: true},
+		{Event: 0, NoTransition: true},
+		{Event: 2, State: Counter{Count: 3}},
+	}
+	if value := len(session.Entries); value != len(expected) {
+		t.Fatalf("Expected len(Entries) To Be Of Value '%d' Actual '%d'", len(expected), value)
+	}
+	for i, entry := range session.Entries {
+		if expected[i].ID == "" {
+			entry.ID = ""
+		}
+		if entry != expected[i] {
+			t.Errorf("Expected Entries[%d] To Equal '%+v' Actual '%+v'", i, expected[i], entry)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := r.Save(path); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	Assert(t, path, buildGuardedCounter)
+
+	failures := &recorder{}
+	Assert(failures, path, func() bloc.BloC[int, Counter, struct{}] { return buildCounter(1)() })
+	if value := len(failures.failures); value != 1 || !strings.Contains(failures.failures[0], "- no transition") {
+		t.Errorf("Expected Failure To Contain '%s' Actual '%v'", "- no transition", failures.failures)
+	}
+}
+
+func TestAssert_ShouldReportDivergence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := Save(path, Recording[int, Counter]{Entries: []Entry[int, Counter]{
+		{Event: 1, State: Counter{Count: 1}},
+		{Event: 2, State: Counter{Count: 3}},
+	}}); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	r := &recorder{}
+	Assert(r, path, buildCounter(2))
+	if value := len(r.failures); value != 1 {
+		t.Fatalf("Expected len(failures) To Be Of Value '%d' Actual '%d'", 1, value)
+	}
+	for _, expected := range []string{"event [0] 1", `- state {"count":1}`, `+ state {"count":2}`} {
+		if !strings.Contains(r.failures[0], expected) {
+			t.Errorf("Expected Failure To Contain '%s' Actual '%s'", expected, r.failures[0])
+		}
+	}
+
+	if err := flag.Set("update", "true"); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	Assert(t, path, buildCounter(2))
+	_ = flag.Set("update", "false")
+
+	updated, err := Load[int, Counter](path)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if value := updated.Entries[1].State.Count; value != 6 {
+		t.Errorf("Expected Updated State To Be Of Value '%d' Actual '%d'", 6, value)
+	}
+	Assert(t, path, buildCounter(2))
+
+	t.Setenv(UpdateEnv, "1")
+	Assert(t, path, buildCounter(3))
+	t.Setenv(UpdateEnv, "false")
+	if updated, _ = Load[int, Counter](path); updated.Entries[1].State.Count != 9 {
+		t.Errorf("Expected Updated State To Be Of Value '%d' Actual '%d'", 9, updated.Entries[1].State.Count)
+	}
+}
+
+func TestLoad_ShouldFailForMissingFile(t *testing.T) {
+	if _, err := Load[int, Counter](filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("Expected Error For Missing Golden File")
+	}
+}
@@ -0,0 +1,334 @@
+package marble
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/clock"
+	"github.com/hijgo/go-bloc/stream"
+)
+
+// Default virtual duration of a single frame of a marble diagram.
+var DefaultFrameDuration = 10 * time.Millisecond
+
+// Default number of frames a Tester runs, before the expectations are compared.
+var DefaultMaxFrames = 1000
+
+// Kind of a Notification.
+type Kind int
+
+const (
+	// A new value was passed into the stream, written as the key of the value in a marble diagram.
+	NextKind Kind = iota
+	// The stream failed, written as '#' in a marble diagram.
+	ErrorKind
+	// The stream completed, written as '|' in a marble diagram.
+	CompleteKind
+)
+
+// An item of a stream driven by marble diagrams. As streams have no notion of errors or completion, both are passed
+// down the stream as notifications as well.
+//
+// T : Type of the values of the stream
+//
+// Kind : Whether the notification carries a value, an error or signals the completion
+//
+// Value : The value, only set for NextKind
+//
+// Err : The error, only set for ErrorKind
+type Notification[T any] struct {
+	Kind  Kind
+	Value T
+	Err   error
+}
+
+// Returns a notification carrying the value.
+func Next[T any](Value T) Notification[T] {
+	return Notification[T]{Kind: NextKind, Value: Value}
+}
+
+// Returns a notification carrying the error.
+func Error[T any](Err error) Notification[T] {
+	return Notification[T]{Kind: ErrorKind, Err: Err}
+}
+
+// Returns a notification signaling the completion.
+func Complete[T any]() Notification[T] {
+	return Notification[T]{Kind: CompleteKind}
+}
+
+// Drives streams from marble diagrams on virtual time and compares the output of streams to marble diagrams.
+//
+// A marble diagram is read from left to right, every character is one frame of FrameDuration:
+//
+// '-' : A frame without any notification
+//
+// 'a' : Any other character is a value, looked up in the values passed along with the diagram
+//
+// '#' : An error, using the error passed along with the diagram
+//
+// '|' : The completion of the stream
+//
+// '(ab)' : Multiple notifications in the same frame, the whole group takes a single frame
+//
+// ' ' : Spaces are ignored and can be used to align diagrams
+//
+// Clock : The virtual clock all streams of the Tester are driven by, should be passed to time based operators
+//
+// FrameDuration : The virtual duration of a single frame
+//
+// MaxFrames : The number of frames Run advances the clock by
+type Tester struct {
+	Clock         *clock.Virtual
+	FrameDuration time.Duration
+	MaxFrames     int
+	t             testing.TB
+	start         time.Time
+	expectations  []func()
+}
+
+type frame[T any] struct {
+	frame        int
+	notification Notification[T]
+}
+
+// Function that should be called if a new Tester is needed.
+//
+// t : Receives the failures, usually a *testing.T
+func CreateTester(t testing.TB) *Tester {
+	start := time.Unix(0, 0)
+	return &Tester{
+		Clock:         clock.CreateVirtualClock(start),
+		FrameDuration: DefaultFrameDuration,
+		MaxFrames:     DefaultMaxFrames,
+		t:             t,
+		start:         start,
+	}
+}
+
+// Creates a stream that will emit the notifications of the marble diagram, once the Tester runs.
+// The stream is delivered by the virtual clock of the Tester and still has to be listened to, usually by an operator.
+//
+// Tester : The Tester driving the stream
+//
+// Marble : The marble diagram describing the notifications of the stream
+//
+// Values : The values of the characters used in the marble diagram
+//
+// Err : The error emitted for '#', may be nil if the diagram contains no error
+func Input[T any](Tester *Tester, Marble string, Values map[rune]T, Err error) *stream.Stream[Notification[T]] {
+	Tester.t.Helper()
+	frames, parseErr := parse(Marble, Values, Err)
+	if parseErr != nil {
+		Tester.t.Fatalf("Invalid marble diagram '%s': %s", Marble, parseErr.Error())
+		return nil
+	}
+
+	input := stream.CreateStream(stream.UnlimitedHistorySize, func(Notification[T]) {})
+	input.SetClock(Tester.Clock)
+	_ = input.SetScheduler(Tester.Clock)
+	for _, f := range frames {
+		notification := f.notification
+		Tester.Clock.AfterFunc(time.Duration(f.frame)*Tester.FrameDuration, func() { input.Add(notification) })
+	}
+	return &input
+}
+
+// Listens to the output stream and compares its notifications to the marble diagram, once Run is called.
+// The stream must not be listened to yet, as it will be delivered by the virtual clock of the Tester.
+//
+// Tester : The Tester driving the stream
+//
+// Output : The stream that should be compared
+//
+// Marble : The marble diagram describing the expected notifications
+//
+// Values : The values of the characters used in the marble diagram
+//
+// Err : The error expected for '#', errors are compared by their message
+func Expect[T any](Tester *Tester, Output *stream.Stream[Notification[T]], Marble string, Values map[rune]T, Err error) {
+	Tester.t.Helper()
+	expected, parseErr := parse(Marble, Values, Err)
+	if parseErr != nil {
+		Tester.t.Fatalf("Invalid marble diagram '%s': %s", Marble, parseErr.Error())
+		return
+	}
+
+	actual := make([]frame[T], 0)
+	Output.SetClock(Tester.Clock)
+	if schedulerErr := Output.SetScheduler(Tester.Clock); schedulerErr != nil {
+		Tester.t.Fatalf("Unexpected error occured: %s", schedulerErr.Error())
+		return
+	}
+	Output.OnNewItem = func(NewItem Notification[T]) {
+		actual = append(actual, frame[T]{frame: Tester.currentFrame(), notification: NewItem})
+	}
+	if listenErr := Output.Listen(); listenErr != nil {
+		Tester.t.Fatalf("Unexpected error occured: %s", listenErr.Error())
+		return
+	}
+
+	Tester.expectations = append(Tester.expectations, func() {
+		Tester.t.Helper()
+		if equalFrames(expected, actual) {
+			return
+		}
+		Tester.t.Errorf("Expected Marble To Equal\n  expected: %s\n  actual:   %s\n  notifications: %s",
+			render(expected, Values), render(actual, Values), describe(actual))
+	})
+}
+
+// Advances the virtual clock by MaxFrames frames, delivering all notifications, and then compares every output
+// registered by Expect to its marble diagram.
+func (m *Tester) Run() {
+	m.t.Helper()
+	m.Clock.AdvanceTo(m.start.Add(time.Duration(m.MaxFrames) * m.FrameDuration))
+	for _, expectation := range m.expectations {
+		expectation()
+	}
+	m.expectations = nil
+}
+
+func (m *Tester) currentFrame() int {
+	return int(m.Clock.Now().Sub(m.start) / m.FrameDuration)
+}
+
+func parse[T any](Marble string, Values map[rune]T, Err error) ([]frame[T], error) {
+	frames := make([]frame[T], 0)
+	current, inGroup := 0, false
+	for _, character := range Marble {
+		var notification Notification[T]
+		switch character {
+		case ' ':
+			continue
+		case '-':
+			if inGroup {
+				return nil, fmt.Errorf("'-' inside of a group")
+			}
+			current++
+			continue
+		case '(':
+			if inGroup {
+				return nil, fmt.Errorf("nested group")
+			}
+			inGroup = true
+			continue
+		case ')':
+			if !inGroup {
+				return nil, fmt.Errorf("')' without '('")
+			}
+			inGroup = false
+			current++
+			continue
+		case '#':
+			notification = Error[T](Err)
+		case '|':
+			notification = Complete[T]()
+		default:
+			value, exists := Values[character]
+			if !exists {
+				return nil, fmt.Errorf("no value for '%c'", character)
+			}
+			notification = Next(value)
+		}
+		frames = append(frames, frame[T]{frame: current, notification: notification})
+		if !inGroup {
+			current++
+		}
+	}
+	if inGroup {
+		return nil, fmt.Errorf("'(' without ')'")
+	}
+	return frames, nil
+}
+
+func equalFrames[T any](Expected []frame[T], Actual []frame[T]) bool {
+	if len(Expected) != len(Actual) {
+		return false
+	}
+	for i := range Expected {
+		e, a := Expected[i], Actual[i]
+		if e.frame != a.frame || e.notification.Kind != a.notification.Kind {
+			return false
+		}
+		switch e.notification.Kind {
+		case NextKind:
+			if !reflect.DeepEqual(e.notification.Value, a.notification.Value) {
+				return false
+			}
+		case ErrorKind:
+			if errorMessage(e.notification.Err) != errorMessage(a.notification.Err) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func render[T any](Frames []frame[T], Values map[rune]T) string {
+	keys := make([]rune, 0, len(Values))
+	for key := range Values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(a, b int) bool { return keys[a] < keys[b] })
+
+	var builder strings.Builder
+	current := 0
+	for i := 0; i < len(Frames); {
+		for ; current < Frames[i].frame; current++ {
+			builder.WriteRune('-')
+		}
+		group := make([]string, 0)
+		for ; i < len(Frames) && Frames[i].frame == current; i++ {
+			group = append(group, symbol(Frames[i].notification, keys, Values))
+		}
+		if len(group) > 1 {
+			builder.WriteString("(" + strings.Join(group, "") + ")")
+		} else {
+			builder.WriteString(group[0])
+		}
+		current++
+	}
+	return builder.String()
+}
+
+func symbol[T any](Notification Notification[T], Keys []rune, Values map[rune]T) string {
+	switch Notification.Kind {
+	case ErrorKind:
+		return "#"
+	case CompleteKind:
+		return "|"
+	}
+	for _, key := range Keys {
+		if reflect.DeepEqual(Values[key], Notification.Value) {
+			return string(key)
+		}
+	}
+	return "?"
+}
+
+func describe[T any](Frames []frame[T]) string {
+	descriptions := make([]string, 0, len(Frames))
+	for _, f := range Frames {
+		switch f.notification.Kind {
+		case ErrorKind:
+			descriptions = append(descriptions, fmt.Sprintf("%d:error(%s)", f.frame, errorMessage(f.notification.Err)))
+		case CompleteKind:
+			descriptions = append(descriptions, fmt.Sprintf("%d:complete", f.frame))
+		default:
+			descriptions = append(descriptions, fmt.Sprintf("%d:next(%+v)", f.frame, f.notification.Value))
+		}
+	}
+	return "[" + strings.Join(descriptions, " ") + "]"
+}
+
+func errorMessage(Err error) string {
+	if Err == nil {
+		return "<nil>"
+	}
+	return Err.Error()
+}
@@ -0,0 +1,143 @@
+package marble
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/clock"
+	"github.com/hijgo/go-bloc/stream"
+)
+
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(Format string, Args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(Format, Args...))
+}
+
+func (r *recorder) Fatalf(Format string, Args ...any) {
+	r.Errorf(Format, Args...)
+}
+
+func listen[T any](t *testing.T, Input *stream.Stream[Notification[T]], OnNewItem func(Notification[T])) {
+	Input.OnNewItem = OnNewItem
+	if err := Input.Listen(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+}
+
+func debounce[T any](t *testing.T, Input *stream.Stream[Notification[T]], Clock clock.Clock, Delay time.Duration) *stream.Stream[Notification[T]] {
+	output := stream.CreateStream(stream.UnlimitedHistorySize, func(Notification[T]) {})
+	var pending clock.Timer
+	var pendingValue *T
+	listen(t, Input, func(NewItem Notification[T]) {
+		if pending != nil {
+			pending.Stop()
+		}
+		switch NewItem.Kind {
+		case NextKind:
+			value := NewItem.Value
+			pendingValue = &value
+			pending = Clock.AfterFunc(Delay, func() {
+				output.Add(Next(value))
+				pendingValue = nil
+			})
+		case CompleteKind:
+			if pendingValue != nil {
+				output.Add(Next(*pendingValue))
+			}
+			output.Add(NewItem)
+		default:
+			output.Add(NewItem)
+		}
+	})
+	return &output
+}
+
+func merge[T any](t *testing.T, First *stream.Stream[Notification[T]], Second *stream.Stream[Notification[T]]) *stream.Stream[Notification[T]] {
+	output := stream.CreateStream(stream.UnlimitedHistorySize, func(Notification[T]) {})
+	completed := 0
+	forward := func(NewItem Notification[T]) {
+		if NewItem.Kind == CompleteKind {
+			completed++
+			if completed < 2 {
+				return
+			}
+		}
+		output.Add(NewItem)
+	}
+	listen(t, First, forward)
+	listen(t, Second, forward)
+	return &output
+}
+
+func TestTester_Debounce(t *testing.T) {
+	m := CreateTester(t)
+	values := map[rune]int{'a': 1, 'b': 2, 'c': 3}
+
+	input := Input(m, "-a-b----c-|", values, nil)
+	Expect(m, debounce(t, input, m.Clock, 3*m.FrameDuration), "------b---(c|)", values, nil)
+	m.Run()
+}
+
+func TestTester_Merge(t *testing.T) {
+	m := CreateTester(t)
+	values := map[rune]string{'a': "a", 'b': "b", 'x': "x", 'y': "y"}
+
+	first := Input(m, "a--b|", values, nil)
+	second := Input(m, "-x-(y|)", values, nil)
+	Expect(m, merge(t, first, second), "ax-(by)|", values, nil)
+	m.Run()
+}
+
+func TestTester_Error(t *testing.T) {
+	m := CreateTester(t)
+	values := map[rune]int{'a': 1}
+	failure := fmt.Errorf("failure")
+
+	input := Input(m, "a--#", values, failure)
+	Expect(m, debounce(t, input, m.Clock, m.FrameDuration), "-a-#", values, fmt.Errorf("failure"))
+	m.Run()
+}
+
+func TestTester_ShouldReportMismatch(t *testing.T) {
+	r := &recorder{}
+	m := CreateTester(r)
+	values := map[rune]int{'a': 1, 'b': 2}
+
+	input := Input(m, "-a-b|", values, nil)
+	Expect(m, debounce(t, input, m.Clock, m.FrameDuration), "--a-b|", values, nil)
+	m.Run()
+
+	if value := len(r.failures); value != 1 {
+		t.Fatalf("Expected len(failures) To Be Of Value '%d' Actual '%d'", 1, value)
+	}
+	for _, expected := range []string{"expected: --a-b|", "actual:   --a-(b|)", "4:next(2) 4:complete"} {
+		if !strings.Contains(r.failures[0], expected) {
+			t.Errorf("Expected Failure To Contain '%s' Actual '%s'", expected, r.failures[0])
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	values := map[rune]int{'a': 1}
+	for _, marble := range []string{"-b", "(a", "a)", "((a))", "(-a)"} {
+		if _, err := parse(marble, values, nil); err == nil {
+			t.Errorf("Expected Error For Marble '%s'", marble)
+		}
+	}
+
+	frames, err := parse(" -a (a|)", values, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if value := render(frames, values); value != "-a(a|)" {
+		t.Errorf("Expected render To Equal '%s' Actual '%s'", "-a(a|)", value)
+	}
+}
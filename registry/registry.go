@@ -0,0 +1,525 @@
+package registry
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hijgo/go-bloc/bloc"
+	err "github.com/hijgo/go-bloc/error"
+)
+
+// The kind of scope, defines how long values provided for this scope live.
+type ScopeKind string
+
+const (
+	// Scope living as long as the whole application, the kind of every registry created by CreateRegistry
+	AppScope ScopeKind = "app"
+	// Scope living as long as a session of a user
+	SessionScope ScopeKind = "session"
+	// Scope living as long as a single request
+	RequestScope ScopeKind = "request"
+)
+
+// Is implemented by every value that needs to be cleaned up when its scope is disposed, like every BloC.
+type Disposable interface {
+	Dispose()
+}
+
+// A container holding BloCs and other values by their type and an optional name.
+// Registries form a tree of scopes, values are looked up in the scope itself first and then in its parent scopes.
+// When a scope is disposed, all of its child scopes and values will be disposed in reverse dependency order.
+type Registry struct {
+	kind      ScopeKind
+	name      string
+	parent    *Registry
+	lock      sync.Mutex
+	providers map[key]*provider
+	instances map[key]*instance
+	order     []*instance
+	children  []*Registry
+	disposed  bool
+	leaks     *leakTracker
+	resolving map[key]bool
+}
+
+type key struct {
+	valueType reflect.Type
+	name      string
+}
+
+func (k key) String() string {
+	if k.name == "" {
+		return k.valueType.String()
+	}
+	return fmt.Sprintf("%s '%s'", k.valueType, k.name)
+}
+
+type provider struct {
+	scope   ScopeKind
+	factory func(*Registry) (any, error)
+}
+
+type instance struct {
+	key   key
+	value any
+	// The value itself if it is a pointer, otherwise a pointer to a copy of it, so methods with a pointer receiver like
+	// Dispose can be called on values registered by value, for example a BloC returned by bloc.CreateBloC.
+	target any
+}
+
+// Options that can be passed when registering or providing a value.
+type Option func(*options)
+
+type options struct {
+	name  string
+	scope ScopeKind
+}
+
+// Registers the value under the given name, so multiple values of the same type can be registered.
+//
+// Name : The name of the value
+func Named(Name string) Option {
+	return func(o *options) { o.name = Name }
+}
+
+// Defines the kind of scope a provided value will be created in and disposed with.
+// When not given, the kind of the scope the provider was added to will be used.
+//
+// Kind : The kind of scope
+func InScope(Kind ScopeKind) Option {
+	return func(o *options) { o.scope = Kind }
+}
+
+// Function that should be called if a new Registry is needed. The new Registry will be of kind AppScope.
+//
+// Every BloC created while the Registry is not disposed will be tracked, to detect BloCs that were never disposed.
+func CreateRegistry() *Registry {
+	r := newRegistry(AppScope, "", nil)
+	r.leaks = createLeakTracker()
+	return r
+}
+
+func newRegistry(Kind ScopeKind, Name string, Parent *Registry) *Registry {
+	return &Registry{
+		kind:      Kind,
+		name:      Name,
+		parent:    Parent,
+		providers: make(map[key]*provider),
+		instances: make(map[key]*instance),
+	}
+}
+
+// Creates a new child scope. Values registered inside the child scope are only visible to the child scope and its own
+// child scopes and will be disposed together with the child scope.
+//
+// Kind : The kind of the new scope
+//
+// Name : The name of the new scope, used in error messages
+func (r *Registry) CreateScope(Kind ScopeKind, Name string) (*Registry, error) {
+	r = r.owner()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.disposed {
+		return nil, disposedError(r)
+	}
+	child := newRegistry(Kind, Name, r)
+	child.leaks = r.leaks
+	r.children = append(r.children, child)
+	return child, nil
+}
+
+// Returns the kind of the scope.
+func (r *Registry) GetKind() ScopeKind {
+	return r.kind
+}
+
+// Returns the name of the scope.
+func (r *Registry) GetName() string {
+	return r.name
+}
+
+func (r *Registry) String() string {
+	r = r.owner()
+	if r.name == "" {
+		return string(r.kind)
+	}
+	return fmt.Sprintf("%s '%s'", r.kind, r.name)
+}
+
+// Registers an already created value, for example a BloC created by bloc.CreateBloC, inside of the scope.
+// Values that depend on other values must be registered after them, so they can be disposed before them.
+// A BloC can be registered by value as returned by bloc.CreateBloC or as pointer, it will be disposed either way.
+//
+// T : The type the value can be looked up by
+//
+// Scope : The scope the value should be registered in
+//
+// Value : The value that should be registered
+//
+// Will return an error if a value of the same type and name is already registered inside of the scope.
+func Register[T any](Scope *Registry, Value T, Options ...Option) error {
+	Scope = Scope.owner()
+	o := collectOptions(Scope, Options)
+	k := key{valueType: typeOf[T](), name: o.name}
+
+	Scope.lock.Lock()
+	defer Scope.lock.Unlock()
+	if Scope.disposed {
+		return disposedError(Scope)
+	}
+	if duplicateErr := Scope.checkDuplicate(k); duplicateErr != nil {
+		return duplicateErr
+	}
+	Scope.addInstance(k, Value)
+	return nil
+}
+
+// Registers a factory that will create the value the first time it is looked up. The value will be created inside the
+// nearest scope of the kind given by InScope and will be disposed together with that scope.
+// The factory can look up the values it depends on, those will then be disposed after the created value.
+//
+// T : The type the value can be looked up by
+//
+// Scope : The scope the factory should be registered in
+//
+// Factory : Function creating the value
+//
+// Will return an error if a value of the same type and name is already registered inside of the scope.
+func Provide[T any](Scope *Registry, Factory func(Scope *Registry) (T, error), Options ...Option) error {
+	Scope = Scope.owner()
+	o := collectOptions(Scope, Options)
+	k := key{valueType: typeOf[T](), name: o.name}
+
+	Scope.lock.Lock()
+	defer Scope.lock.Unlock()
+	if Scope.disposed {
+		return disposedError(Scope)
+	}
+	if duplicateErr := Scope.checkDuplicate(k); duplicateErr != nil {
+		return duplicateErr
+	}
+	Scope.providers[k] = &provider{
+		scope:   o.scope,
+		factory: func(FactoryScope *Registry) (any, error) { return Factory(FactoryScope) },
+	}
+	return nil
+}
+
+// Looks up the unnamed value of type T, starting at the given scope and continuing with its parent scopes.
+//
+// T : The type of the wanted value
+//
+// Scope : The scope the lookup should start at
+//
+// Will return an error if no such value is registered or the value could not be created.
+func Get[T any](Scope *Registry) (T, error) {
+	return GetNamed[T](Scope, "")
+}
+
+// Looks up the value of type T with the given name, starting at the given scope and continuing with its parent scopes.
+//
+// T : The type of the wanted value
+//
+// Scope : The scope the lookup should start at
+//
+// Name : The name of the wanted value
+//
+// Will return an error if no such value is registered or the value could not be created.
+func GetNamed[T any](Scope *Registry, Name string) (T, error) {
+	var zero T
+	resolving := Scope.resolving
+	if resolving == nil {
+		resolving = make(map[key]bool)
+	}
+	value, getErr := Scope.get(key{valueType: typeOf[T](), name: Name}, resolving)
+	if getErr != nil {
+		return zero, getErr
+	}
+	return value.(T), nil
+}
+
+// Same as Get, but will panic instead of returning an error.
+func MustGet[T any](Scope *Registry) T {
+	value, getErr := Get[T](Scope)
+	if getErr != nil {
+		panic(getErr)
+	}
+	return value
+}
+
+func (r *Registry) get(Key key, Resolving map[key]bool) (any, error) {
+	for scope := r; scope != nil; scope = scope.parent {
+		scope.lock.Lock()
+		if scope.disposed {
+			scope.lock.Unlock()
+			return nil, disposedError(scope)
+		}
+		if found, exists := scope.instances[Key]; exists {
+			scope.lock.Unlock()
+			return found.value, nil
+		}
+		p, exists := scope.providers[Key]
+		scope.lock.Unlock()
+		if exists {
+			return r.create(Key, p, Resolving)
+		}
+	}
+	return nil, &err.Error{
+		Context: "Cannot look up value, it was never registered!",
+		Err:     fmt.Errorf("%s not registered in scope %s", Key, r),
+	}
+}
+
+func (r *Registry) create(Key key, Provider *provider, Resolving map[key]bool) (any, error) {
+	target := r
+	for target != nil && target.kind != Provider.scope {
+		target = target.parent
+	}
+	if target == nil {
+		return nil, &err.Error{
+			Context: "Cannot create value, no scope of the wanted kind found!",
+			Err:     fmt.Errorf("%s needs a %s scope, but was looked up in scope %s", Key, Provider.scope, r),
+		}
+	}
+
+	target.lock.Lock()
+	if found, exists := target.instances[Key]; exists {
+		target.lock.Unlock()
+		return found.value, nil
+	}
+	target.lock.Unlock()
+
+	if Resolving[Key] {
+		return nil, &err.Error{
+			Context: "Cannot create value, it depends on itself!",
+			Err:     fmt.Errorf("dependency cycle detected while creating %s", Key),
+		}
+	}
+	Resolving[Key] = true
+	defer delete(Resolving, Key)
+
+	value, factoryErr := Provider.factory(target.resolvingView(Resolving))
+	if factoryErr != nil {
+		return nil, &err.Error{
+			Context: "Cannot create value, factory failed!",
+			Err:     fmt.Errorf("creating %s failed: %w", Key, factoryErr),
+		}
+	}
+
+	target.lock.Lock()
+	defer target.lock.Unlock()
+	if target.disposed {
+		if disposable, ok := addressable(value).(Disposable); ok {
+			disposable.Dispose()
+		}
+		return nil, disposedError(target)
+	}
+	if found, exists := target.instances[Key]; exists {
+		return found.value, nil
+	}
+	target.addInstance(Key, value)
+	return value, nil
+}
+
+// Disposes all child scopes, then every value registered inside of the scope in reverse dependency order.
+// Values are disposed before the values they depend on, values registered by Register are disposed in reverse
+// registration order.
+//
+// When the Registry created by CreateRegistry is disposed, will return a LeakError if any BloC created while the
+// Registry existed was not disposed.
+func (r *Registry) Dispose() error {
+	r = r.owner()
+	r.lock.Lock()
+	if r.disposed {
+		r.lock.Unlock()
+		return nil
+	}
+	r.disposed = true
+	children := r.children
+	order := r.order
+	r.children = nil
+	r.lock.Unlock()
+
+	for i := len(children) - 1; i >= 0; i-- {
+		_ = children[i].Dispose()
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		if disposable, ok := order[i].target.(Disposable); ok {
+			disposable.Dispose()
+		}
+	}
+
+	if r.parent != nil {
+		r.parent.removeChild(r)
+		return nil
+	}
+	return r.leaks.close()
+}
+
+// Returns all BloCs that were created while the Registry existed, are not disposed and are not registered inside of
+// any scope of the Registry. Those BloCs will never be disposed by the Registry.
+func (r *Registry) GetLeaks() []bloc.Inspectable {
+	root := r
+	for root.parent != nil {
+		root = root.parent
+	}
+	registered := make(map[uint64]bool)
+	root.collectBloCs(registered)
+	return r.leaks.live(registered)
+}
+
+func (r *Registry) collectBloCs(Registered map[uint64]bool) {
+	r.lock.Lock()
+	children := r.children
+	for _, i := range r.order {
+		if inspectable, ok := i.target.(bloc.Inspectable); ok {
+			Registered[inspectable.GetID()] = true
+		}
+	}
+	r.lock.Unlock()
+
+	for _, child := range children {
+		child.collectBloCs(Registered)
+	}
+}
+
+func (r *Registry) removeChild(Child *Registry) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for i, child := range r.children {
+		if child == Child {
+			r.children = append(r.children[:i:i], r.children[i+1:]...)
+			return
+		}
+	}
+}
+
+func (r *Registry) checkDuplicate(Key key) error {
+	_, instanceExists := r.instances[Key]
+	_, providerExists := r.providers[Key]
+	if instanceExists || providerExists {
+		return &err.Error{
+			Context: "Cannot register value, already registered!",
+			Err:     fmt.Errorf("%s already registered in scope %s", Key, r),
+		}
+	}
+	return nil
+}
+
+func (r *Registry) addInstance(Key key, Value any) {
+	i := &instance{key: Key, value: Value, target: addressable(Value)}
+	r.instances[Key] = i
+	r.order = append(r.order, i)
+}
+
+// Returns the value if it is nil or a pointer, otherwise a pointer to a copy of the value. Copies of a BloC share its
+// state, so disposing the copy disposes the BloC.
+func addressable(Value any) any {
+	value := reflect.ValueOf(Value)
+	if !value.IsValid() || value.Kind() == reflect.Pointer {
+		return Value
+	}
+	pointer := reflect.New(value.Type())
+	pointer.Elem().Set(value)
+	return pointer.Interface()
+}
+
+// Returns a view on the scope that is passed to factories, so lookups done by a factory can detect dependency cycles.
+func (r *Registry) resolvingView(Resolving map[key]bool) *Registry {
+	view := newRegistry("", r.name, r)
+	view.leaks = r.leaks
+	view.resolving = Resolving
+	return view
+}
+
+// Returns the scope itself, or the scope a view was created for.
+func (r *Registry) owner() *Registry {
+	if r.resolving != nil {
+		return r.parent
+	}
+	return r
+}
+
+func collectOptions(Scope *Registry, Options []Option) options {
+	o := options{scope: Scope.kind}
+	for _, option := range Options {
+		option(&o)
+	}
+	return o
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func disposedError(Scope *Registry) error {
+	return &err.Error{
+		Context: "Cannot use scope, scope was disposed!",
+		Err:     fmt.Errorf("scope %s was disposed", Scope),
+	}
+}
+
+// Returned when BloCs were not disposed although the Registry was disposed.
+//
+// BloCs : The BloCs that were not disposed
+type LeakError struct {
+	BloCs []bloc.Inspectable
+}
+
+func (l *LeakError) Error() string {
+	names := make([]string, 0, len(l.BloCs))
+	for _, b := range l.BloCs {
+		names = append(names, fmt.Sprintf("%s (%d)", b.GetName(), b.GetID()))
+	}
+	return fmt.Sprintf("%d bloc(s) were never disposed: %s", len(l.BloCs), strings.Join(names, ", "))
+}
+
+// Keeps track of every BloC created and not yet disposed.
+type leakTracker struct {
+	lock           sync.Mutex
+	bloCs          map[uint64]bloc.Inspectable
+	removeObserver func()
+}
+
+func createLeakTracker() *leakTracker {
+	l := &leakTracker{bloCs: make(map[uint64]bloc.Inspectable)}
+	l.removeObserver = bloc.AddLifecycleObserver(l)
+	return l
+}
+
+func (l *leakTracker) OnCreate(BloC bloc.Inspectable) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.bloCs[BloC.GetID()] = BloC
+}
+
+func (l *leakTracker) OnDispose(BloC bloc.Inspectable) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	delete(l.bloCs, BloC.GetID())
+}
+
+func (l *leakTracker) live(Excluded map[uint64]bool) []bloc.Inspectable {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	live := make([]bloc.Inspectable, 0)
+	for id, b := range l.bloCs {
+		if !Excluded[id] {
+			live = append(live, b)
+		}
+	}
+	sort.Slice(live, func(a, b int) bool { return live[a].GetID() < live[b].GetID() })
+	return live
+}
+
+func (l *leakTracker) close() error {
+	l.removeObserver()
+	leaks := l.live(nil)
+	if len(leaks) == 0 {
+		return nil
+	}
+	return &LeakError{BloCs: leaks}
+}
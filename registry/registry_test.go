@@ -0,0 +1,235 @@
+package registry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hijgo/go-bloc/bloc"
+	"github.com/hijgo/go-bloc/event"
+)
+
+type Event struct {
+	Data int
+}
+
+type BD struct {
+	BD string
+}
+
+type State struct {
+	State int
+}
+
+type CounterBloC = bloc.BloC[Event, State, BD]
+
+func createCounterBloC() *CounterBloC {
+	b := bloc.CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { return State{State: E.Data.Data} })
+	return &b
+}
+
+type service struct {
+	name     string
+	disposed *[]string
+}
+
+func (s *service) Dispose() {
+	*s.disposed = append(*s.disposed, s.name)
+}
+
+func TestRegisterAndGet(t *testing.T) {
+	r := CreateRegistry()
+	b := createCounterBloC()
+
+	if err := Register(r, b); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	found, err := Get[*CounterBloC](r)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if found != b {
+		t.Errorf("Expected Get To Return Registered BloC")
+	}
+
+	if err := r.Dispose(); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	if value := b.IsDisposed(); !value {
+		t.Errorf("Expected IsDisposed To Equal '%t' Actual '%t'", true, value)
+	}
+}
+
+func TestRegisterShouldDisposeBloCRegisteredByValue(t *testing.T) {
+	r := CreateRegistry()
+	b := bloc.CreateBloC(BD{}, func(E event.Event[Event], BD *BD) State { return State{State: E.Data.Data} })
+
+	if err := Register(r, b); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if value := len(r.GetLeaks()); value != 0 {
+		t.Errorf("Expected len(GetLeaks) To Equal '%d' Actual '%d'", 0, value)
+	}
+	found, err := Get[CounterBloC](r)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if found.GetID() != b.GetID() {
+		t.Errorf("Expected GetID To Equal '%d' Actual '%d'", b.GetID(), found.GetID())
+	}
+
+	if err := r.Dispose(); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	if value := b.IsDisposed(); !value {
+		t.Errorf("Expected IsDisposed To Equal '%t' Actual '%t'", true, value)
+	}
+}
+
+func TestRegisterNamed(t *testing.T) {
+	r := CreateRegistry()
+	defer func() { _ = r.Dispose() }()
+	first, second := createCounterBloC(), createCounterBloC()
+
+	if err := Register(r, first, Named("first")); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := Register(r, second, Named("second")); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	if found, _ := GetNamed[*CounterBloC](r, "second"); found != second {
+		t.Errorf("Expected GetNamed To Return BloC Named '%s'", "second")
+	}
+	if _, err := Get[*CounterBloC](r); err == nil {
+		t.Errorf("Expected Get To Return Error When No Unnamed Value Registered")
+	}
+}
+
+func TestRegisterShouldReturnErrorWhenDuplicate(t *testing.T) {
+	r := CreateRegistry()
+	defer func() { _ = r.Dispose() }()
+	b := createCounterBloC()
+
+	if err := Register(r, b); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := Register(r, createCounterBloC()); err == nil {
+		t.Errorf("Expected Register To Return Error When Already Registered")
+	}
+	if err := Provide(r, func(*Registry) (*CounterBloC, error) { return createCounterBloC(), nil }); err == nil {
+		t.Errorf("Expected Provide To Return Error When Already Registered")
+	}
+}
+
+func TestRegistry_DisposeInReverseDependencyOrder(t *testing.T) {
+	r := CreateRegistry()
+	disposed := make([]string, 0)
+
+	_ = Provide(r, func(Scope *Registry) (*service, error) {
+		return &service{name: "auth", disposed: &disposed}, nil
+	}, Named("auth"))
+	_ = Provide(r, func(Scope *Registry) (*service, error) {
+		if _, err := GetNamed[*service](Scope, "auth"); err != nil {
+			return nil, err
+		}
+		return &service{name: "cart", disposed: &disposed}, nil
+	}, Named("cart"))
+	_ = Register(r, &service{name: "logger", disposed: &disposed}, Named("logger"))
+
+	if _, err := GetNamed[*service](r, "cart"); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := r.Dispose(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	for i, expected := range []string{"cart", "auth", "logger"} {
+		if value := disposed[i]; value != expected {
+			t.Errorf("Expected disposed To Equal '%s' At Position '%d' Actual '%s'", expected, i, value)
+		}
+	}
+}
+
+func TestProvideShouldReturnErrorOnDependencyCycle(t *testing.T) {
+	r := CreateRegistry()
+	defer func() { _ = r.Dispose() }()
+
+	_ = Provide(r, func(Scope *Registry) (*service, error) {
+		_, err := GetNamed[*service](Scope, "b")
+		return &service{}, err
+	}, Named("a"))
+	_ = Provide(r, func(Scope *Registry) (*service, error) {
+		_, err := GetNamed[*service](Scope, "a")
+		return &service{}, err
+	}, Named("b"))
+
+	if _, err := GetNamed[*service](r, "a"); err == nil {
+		t.Errorf("Expected GetNamed To Return Error On Dependency Cycle")
+	}
+}
+
+func TestRegistry_CreateScope(t *testing.T) {
+	r := CreateRegistry()
+	defer func() { _ = r.Dispose() }()
+	disposed := make([]string, 0)
+
+	_ = Provide(r, func(Scope *Registry) (*service, error) {
+		return &service{name: Scope.GetName(), disposed: &disposed}, nil
+	}, InScope(SessionScope))
+
+	if _, err := Get[*service](r); err == nil {
+		t.Errorf("Expected Get To Return Error When No Session Scope Exists")
+	}
+
+	session, err := r.CreateScope(SessionScope, "alice")
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	request, _ := session.CreateScope(RequestScope, "request")
+
+	fromRequest, err := Get[*service](request)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	fromSession := MustGet[*service](session)
+	if fromRequest != fromSession || fromSession.name != "alice" {
+		t.Errorf("Expected Session Scoped Value To Be Shared Inside Of Session")
+	}
+
+	other, _ := r.CreateScope(SessionScope, "bob")
+	if MustGet[*service](other) == fromSession {
+		t.Errorf("Expected Session Scoped Value To Differ Between Sessions")
+	}
+
+	if err := session.Dispose(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if value := len(disposed); value != 1 || disposed[0] != "alice" {
+		t.Errorf("Expected disposed To Equal '%v' Actual '%v'", []string{"alice"}, disposed)
+	}
+	if _, err := Get[*service](request); err == nil {
+		t.Errorf("Expected Get To Return Error When Scope Disposed")
+	}
+}
+
+func TestRegistry_GetLeaks(t *testing.T) {
+	r := CreateRegistry()
+	registered := createCounterBloC()
+	leaked := createCounterBloC()
+	_ = Register(r, registered)
+
+	leaks := r.GetLeaks()
+	if value := len(leaks); value != 1 || leaks[0].GetID() != leaked.GetID() {
+		t.Fatalf("Expected GetLeaks To Contain Leaked BloC '%d' Actual '%v'", leaked.GetID(), leaks)
+	}
+
+	err := r.Dispose()
+	var leakErr *LeakError
+	if !errors.As(err, &leakErr) {
+		t.Fatalf("Expected Dispose To Return LeakError Actual '%v'", err)
+	}
+	if value := len(leakErr.BloCs); value != 1 {
+		t.Errorf("Expected len(BloCs) To Equal '%d' Actual '%d'", 1, value)
+	}
+	leaked.Dispose()
+}
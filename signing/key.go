@@ -0,0 +1,231 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"sync"
+
+	err "github.com/hijgo/go-bloc/error"
+)
+
+// A key used to sign and verify serialized events, identified by its id so it can be rotated.
+// Implementations must be safe for concurrent use.
+type Key interface {
+	// Returns the id written into signed events, so verifiers can pick the matching key
+	GetID() string
+	// Returns the signature of the payload, or an error if the key can only verify
+	Sign(Payload []byte) ([]byte, error)
+	// Returns true if the signature matches the payload
+	Verify(Payload []byte, Signature []byte) bool
+}
+
+// Key signing with HMAC-SHA256, the same secret is used to sign and to verify.
+type HMACKey struct {
+	id     string
+	secret []byte
+}
+
+// Function that should be called if a new HMACKey is needed.
+//
+// ID : The id of the key
+//
+// Secret : The shared secret, should be at least 32 random bytes
+func CreateHMACKey(ID string, Secret []byte) *HMACKey {
+	return &HMACKey{id: ID, secret: append([]byte(nil), Secret...)}
+}
+
+// Returns the id of the key.
+func (k *HMACKey) GetID() string {
+	return k.id
+}
+
+// Returns the HMAC-SHA256 of the payload.
+//
+// Payload : The serialized event
+func (k *HMACKey) Sign(Payload []byte) ([]byte, error) {
+	mac := hmac.New(sha256.New, k.secret)
+	mac.Write(Payload)
+	return mac.Sum(nil), nil
+}
+
+// Returns true if the signature is the HMAC-SHA256 of the payload.
+//
+// Payload : The serialized event
+//
+// Signature : The signature that should be checked
+func (k *HMACKey) Verify(Payload []byte, Signature []byte) bool {
+	expected, _ := k.Sign(Payload)
+	return hmac.Equal(expected, Signature)
+}
+
+// Key signing with Ed25519. Created from a public key only, it can verify but not sign.
+type Ed25519Key struct {
+	id         string
+	privateKey ed25519.PrivateKey
+	publicKey  ed25519.PublicKey
+}
+
+// Function that should be called if a new Ed25519Key able to sign is needed.
+//
+// ID : The id of the key
+//
+// PrivateKey : The private key used to sign, its public key is used to verify
+func CreateEd25519Key(ID string, PrivateKey ed25519.PrivateKey) *Ed25519Key {
+	return &Ed25519Key{id: ID, privateKey: PrivateKey, publicKey: PrivateKey.Public().(ed25519.PublicKey)}
+}
+
+// Function that should be called if a new Ed25519Key is needed that can only verify, for example on the receiving
+// side of signed events.
+//
+// ID : The id of the key
+//
+// PublicKey : The public key used to verify
+func CreateEd25519PublicKey(ID string, PublicKey ed25519.PublicKey) *Ed25519Key {
+	return &Ed25519Key{id: ID, publicKey: PublicKey}
+}
+
+// Returns the id of the key.
+func (k *Ed25519Key) GetID() string {
+	return k.id
+}
+
+// Returns the Ed25519 signature of the payload.
+//
+// Payload : The serialized event
+//
+// Will return an error if the key was created from a public key only.
+func (k *Ed25519Key) Sign(Payload []byte) ([]byte, error) {
+	if k.privateKey == nil {
+		return nil, &err.Error{
+			Context: "Cannot sign payload, key has no private key!",
+			Err:     fmt.Errorf("key '%s' can only verify", k.id),
+		}
+	}
+	return ed25519.Sign(k.privateKey, Payload), nil
+}
+
+// Returns true if the signature is a valid Ed25519 signature of the payload.
+//
+// Payload : The serialized event
+//
+// Signature : The signature that should be checked
+func (k *Ed25519Key) Verify(Payload []byte, Signature []byte) bool {
+	return len(k.publicKey) == ed25519.PublicKeySize && ed25519.Verify(k.publicKey, Payload, Signature)
+}
+
+// Holds all keys accepted when verifying and the key currently used to sign. To rotate keys, add the new key, switch
+// to it with Rotate and remove the old key once no events signed with it are expected anymore.
+type Keyring struct {
+	lock       sync.RWMutex
+	keys       map[string]Key
+	signingKey string
+}
+
+// Function that should be called if a new Keyring is needed.
+//
+// Keys : The keys accepted when verifying, the first key is used to sign
+//
+// Will return an error if two keys have the same id.
+func CreateKeyring(Keys ...Key) (*Keyring, error) {
+	keyring := &Keyring{keys: make(map[string]Key)}
+	for _, key := range Keys {
+		if addErr := keyring.Add(key); addErr != nil {
+			return nil, addErr
+		}
+	}
+	if len(Keys) > 0 {
+		keyring.signingKey = Keys[0].GetID()
+	}
+	return keyring, nil
+}
+
+// Adds a key accepted when verifying.
+//
+// Key : The key that should be added
+//
+// Will return an error if a key with the same id was already added.
+func (k *Keyring) Add(Key Key) error {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if _, exists := k.keys[Key.GetID()]; exists {
+		return &err.Error{
+			Context: "Cannot add key, id already used!",
+			Err:     fmt.Errorf("key '%s' already added", Key.GetID()),
+		}
+	}
+	k.keys[Key.GetID()] = Key
+	return nil
+}
+
+// Switches the key used to sign to the key with the given id.
+//
+// ID : The id of a key added before
+//
+// Will return an error if no key with the id was added.
+func (k *Keyring) Rotate(ID string) error {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if _, exists := k.keys[ID]; !exists {
+		return &err.Error{
+			Context: "Cannot rotate key, key is unknown!",
+			Err:     fmt.Errorf("key '%s' not added", ID),
+		}
+	}
+	k.signingKey = ID
+	return nil
+}
+
+// Removes a key, so events signed with it will no longer be accepted. If it is the key used to sign, signing will fail
+// until another key is chosen with Rotate.
+//
+// ID : The id of the key that should be removed
+func (k *Keyring) Remove(ID string) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	delete(k.keys, ID)
+	if k.signingKey == ID {
+		k.signingKey = ""
+	}
+}
+
+// Returns the id of the key used to sign, empty if no key is chosen.
+func (k *Keyring) GetSigningKeyID() string {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	return k.signingKey
+}
+
+// Returns the ids of all keys accepted when verifying, sorted.
+func (k *Keyring) GetKeyIDs() []string {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (k *Keyring) getKey(ID string) (Key, bool) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	key, exists := k.keys[ID]
+	return key, exists
+}
+
+func (k *Keyring) getSigningKey() (Key, error) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	key, exists := k.keys[k.signingKey]
+	if !exists {
+		return nil, &err.Error{
+			Context: "Cannot sign event, no signing key chosen!",
+			Err:     fmt.Errorf("signing key '%s' not added", k.signingKey),
+		}
+	}
+	return key, nil
+}
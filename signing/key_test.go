@@ -0,0 +1,83 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"reflect"
+	"testing"
+)
+
+func TestKeys_SignVerify(t *testing.T) {
+	_, privateKey, generateErr := ed25519.GenerateKey(nil)
+	if generateErr != nil {
+		t.Fatalf("Unexpected error occured: %s", generateErr.Error())
+	}
+	payload := []byte("payload")
+
+	for _, key := range []Key{CreateHMACKey("hmac", []byte("secret")), CreateEd25519Key("ed25519", privateKey)} {
+		signature, err := key.Sign(payload)
+		if err != nil {
+			t.Fatalf("Unexpected error occured: %s", err.Error())
+		}
+		if !key.Verify(payload, signature) {
+			t.Errorf("Expected Signature Of Key '%s' To Be Valid", key.GetID())
+		}
+		if key.Verify([]byte("tampered"), signature) {
+			t.Errorf("Expected Signature Of Key '%s' Not To Match Tampered Payload", key.GetID())
+		}
+	}
+
+	if CreateHMACKey("other", []byte("other secret")).Verify(payload, mustSign(t, CreateHMACKey("hmac", []byte("secret")), payload)) {
+		t.Errorf("Expected Signature Not To Match Other Secret")
+	}
+
+	public := CreateEd25519PublicKey("ed25519", privateKey.Public().(ed25519.PublicKey))
+	if !public.Verify(payload, mustSign(t, CreateEd25519Key("ed25519", privateKey), payload)) {
+		t.Errorf("Expected Public Key To Verify Signature Of Private Key")
+	}
+	if _, err := public.Sign(payload); err == nil {
+		t.Errorf("Expected Error When Signing With Public Key")
+	}
+}
+
+func mustSign(t *testing.T, Key Key, Payload []byte) []byte {
+	signature, err := Key.Sign(Payload)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	return signature
+}
+
+func TestKeyring(t *testing.T) {
+	keyring, err := CreateKeyring(CreateHMACKey("2024", []byte("a")), CreateHMACKey("2025", []byte("b")))
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if value := keyring.GetSigningKeyID(); value != "2024" {
+		t.Errorf("Expected GetSigningKeyID To Equal '%s' Actual '%s'", "2024", value)
+	}
+	if err := keyring.Add(CreateHMACKey("2024", []byte("c"))); err == nil {
+		t.Errorf("Expected Error When Adding Key Twice")
+	}
+	if err := keyring.Rotate("unknown"); err == nil {
+		t.Errorf("Expected Error When Rotating To Unknown Key")
+	}
+	if err := keyring.Rotate("2025"); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+
+	keyring.Remove("2024")
+	if value := keyring.GetKeyIDs(); !reflect.DeepEqual(value, []string{"2025"}) {
+		t.Errorf("Expected GetKeyIDs To Equal '%v' Actual '%v'", []string{"2025"}, value)
+	}
+	keyring.Remove("2025")
+	if value := keyring.GetSigningKeyID(); value != "" {
+		t.Errorf("Expected GetSigningKeyID To Equal '%s' Actual '%s'", "", value)
+	}
+	if _, err := keyring.getSigningKey(); err == nil {
+		t.Errorf("Expected Error When No Signing Key Is Chosen")
+	}
+
+	if _, err := CreateKeyring(CreateHMACKey("a", nil), CreateHMACKey("a", nil)); err == nil {
+		t.Errorf("Expected Error When Creating Keyring With Duplicate Ids")
+	}
+}
@@ -0,0 +1,182 @@
+package signing
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hijgo/go-bloc/bloc"
+	"github.com/hijgo/go-bloc/codec"
+	err "github.com/hijgo/go-bloc/error"
+	"github.com/hijgo/go-bloc/event"
+)
+
+// Names of the headers holding the signature of an event and the id of the key it was signed with. Both are valid
+// CloudEvents extension names, so signatures survive the conversion done by the cloudevents package.
+const (
+	SignatureHeader = "signature"
+	KeyIDHeader     = "signaturekey"
+)
+
+// The values of an event covered by its signature. The time of creation and the sequence are not covered, as both are
+// reassigned when an event is added to a BloC. Always encoded with codec.CBOR, which sorts the keys of maps, and holds
+// the headers as pairs sorted by their key, so the same event always results in the same bytes whatever Codec is used.
+type payload struct {
+	KeyID         string        `json:"keyId" cbor:"keyId"`
+	ID            string        `json:"id" cbor:"id"`
+	CorrelationID string        `json:"correlationId" cbor:"correlationId"`
+	CausationID   string        `json:"causationId" cbor:"causationId"`
+	Source        string        `json:"source" cbor:"source"`
+	Headers       []header      `json:"headers" cbor:"headers"`
+	Version       uint          `json:"version" cbor:"version"`
+	Priority      int           `json:"priority" cbor:"priority"`
+	Deadline      time.Time     `json:"deadline" cbor:"deadline"`
+	TTL           time.Duration `json:"ttl" cbor:"ttl"`
+	Type          string        `json:"type" cbor:"type"`
+	Data          []byte        `json:"data" cbor:"data"`
+}
+
+type header struct {
+	Key   string `json:"key" cbor:"key"`
+	Value string `json:"value" cbor:"value"`
+}
+
+// Signs events and verifies their signatures. Signer and verifier must use the same Codec and Types.
+//
+// T : The type of data carried with by the events
+//
+// Keys : The keys used to sign and verify
+//
+// Codec : The codec used to serialize the data of an event, codec.CBOR by CreateSigner. Must always encode equal
+// values to equal bytes, so codec.Gob, which encodes maps in random order, is rejected
+//
+// Types : Registry used to serialize the data with its concrete type, nil if T is not an interface
+type Signer[T any] struct {
+	Keys  *Keyring
+	Codec codec.Codec
+	Types *codec.Registry
+}
+
+// Function that should be called if a new Signer is needed.
+//
+// T : The type of data carried with by the events
+//
+// Keys : The keys used to sign and verify
+func CreateSigner[T any](Keys *Keyring) Signer[T] {
+	return Signer[T]{Keys: Keys, Codec: codec.CBOR}
+}
+
+// Returns a copy of the event carrying the signature and the id of the signing key of the Keyring as headers.
+// The id, correlation id, causation id, source, headers, version, priority, expiry and data of the event are signed.
+//
+// Event : The event that should be signed
+func (s Signer[T]) Sign(Event event.Event[T]) (event.Event[T], error) {
+	key, keyErr := s.Keys.getSigningKey()
+	if keyErr != nil {
+		return event.Event[T]{}, keyErr
+	}
+	serialized, serializeErr := s.serialize(Event, key.GetID())
+	if serializeErr != nil {
+		return event.Event[T]{}, serializeErr
+	}
+	signature, signErr := key.Sign(serialized)
+	if signErr != nil {
+		return event.Event[T]{}, signErr
+	}
+
+	signed := Event
+	event.WithHeader(KeyIDHeader, key.GetID())(&signed.Metadata)
+	event.WithHeader(SignatureHeader, base64.StdEncoding.EncodeToString(signature))(&signed.Metadata)
+	return signed, nil
+}
+
+// Checks that the event was signed by a key of the Keyring and was not modified since.
+//
+// Event : The event that should be verified
+//
+// Will return an error if the event is unsigned, was signed with an unknown key or its signature does not match.
+func (s Signer[T]) Verify(Event event.Event[T]) error {
+	keyID, signature := Event.Headers.Get(KeyIDHeader), Event.Headers.Get(SignatureHeader)
+	if keyID == "" || signature == "" {
+		return &err.Error{
+			Context: "Cannot verify event, event is not signed!",
+			Err:     fmt.Errorf("event '%s' has no signature", Event.ID),
+		}
+	}
+	key, exists := s.Keys.getKey(keyID)
+	if !exists {
+		return &err.Error{
+			Context: "Cannot verify event, key is unknown!",
+			Err:     fmt.Errorf("event '%s' signed with unknown key '%s'", Event.ID, keyID),
+		}
+	}
+	decoded, decodeErr := base64.StdEncoding.DecodeString(signature)
+	if decodeErr != nil {
+		return &err.Error{Context: "Cannot verify event, signature is malformed!", Err: decodeErr}
+	}
+	serialized, serializeErr := s.serialize(Event, keyID)
+	if serializeErr != nil {
+		return serializeErr
+	}
+	if !key.Verify(serialized, decoded) {
+		return &err.Error{
+			Context: "Cannot verify event, signature does not match!",
+			Err:     fmt.Errorf("invalid signature of event '%s' with key '%s'", Event.ID, keyID),
+		}
+	}
+	return nil
+}
+
+// Returns a middleware rejecting every event that is unsigned or whose signature does not match, so tampered events
+// never reach mapEventToState. Should be the first middleware of the BloC, as earlier middlewares may modify events.
+func (s Signer[T]) Middleware() bloc.EventMiddleware[T] {
+	return func(NewEvent event.Event[T], Next bloc.EventHandler[T]) error {
+		if verifyErr := s.Verify(NewEvent); verifyErr != nil {
+			return verifyErr
+		}
+		return Next(NewEvent)
+	}
+}
+
+func (s Signer[T]) serialize(Event event.Event[T], KeyID string) ([]byte, error) {
+	signed := payload{
+		KeyID:         KeyID,
+		ID:            Event.ID,
+		CorrelationID: Event.CorrelationID,
+		CausationID:   Event.CausationID,
+		Source:        Event.Source,
+		Version:       Event.Version,
+		Priority:      Event.Priority,
+		Deadline:      Event.Deadline.UTC(),
+		TTL:           Event.TTL,
+	}
+	for key, value := range Event.Headers.ToMap() {
+		if key != SignatureHeader && key != KeyIDHeader {
+			signed.Headers = append(signed.Headers, header{Key: key, Value: value})
+		}
+	}
+	sort.Slice(signed.Headers, func(a, b int) bool { return signed.Headers[a].Key < signed.Headers[b].Key })
+
+	if s.Codec == nil || s.Codec.Name() == codec.Gob.Name() {
+		return nil, &err.Error{
+			Context: "Cannot serialize event, codec is not deterministic!",
+			Err:     fmt.Errorf("codec '%v' cannot be used to sign events", s.Codec),
+		}
+	}
+
+	if s.Types != nil {
+		envelope, wrapErr := s.Types.Wrap(s.Codec, Event.Data)
+		if wrapErr != nil {
+			return nil, wrapErr
+		}
+		signed.Type, signed.Data = envelope.Type, envelope.Data
+	} else {
+		data, encodeErr := s.Codec.Marshal(Event.Data)
+		if encodeErr != nil {
+			return nil, encodeErr
+		}
+		signed.Data = data
+	}
+	return codec.CBOR.Marshal(signed)
+}
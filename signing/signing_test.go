@@ -0,0 +1,191 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/bloc"
+	"github.com/hijgo/go-bloc/clock"
+	"github.com/hijgo/go-bloc/codec"
+	"github.com/hijgo/go-bloc/event"
+)
+
+type Transfer struct {
+	From   string
+	To     string
+	Amount int
+}
+
+type Account struct {
+	Balance int
+}
+
+func createSigner(t *testing.T) Signer[Transfer] {
+	keyring, err := CreateKeyring(CreateHMACKey("2024", []byte("secret")))
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	return CreateSigner[Transfer](keyring)
+}
+
+func TestSigner_SignVerify(t *testing.T) {
+	signer := createSigner(t)
+	original := event.CreateEvent(Transfer{From: "a", To: "b", Amount: 10}, event.WithSource("edge"),
+		event.WithHeader("tenant", "x"), event.WithDeadline(time.Now().Add(time.Minute)))
+
+	signed, err := signer.Sign(original)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if value := signed.Headers.Get(KeyIDHeader); value != "2024" {
+		t.Errorf("Expected Header '%s' To Equal '%s' Actual '%s'", KeyIDHeader, "2024", value)
+	}
+	if _, signedOriginal := original.Headers.Lookup(SignatureHeader); signedOriginal {
+		t.Errorf("Expected Headers Of Original Event Not To Be Modified")
+	}
+	if err := signer.Verify(signed); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+
+	resequenced := signed.WithSequence(42)
+	resequenced.Time = resequenced.Time.Add(time.Hour)
+	if err := signer.Verify(resequenced); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+
+	tampered := []func(e *event.Event[Transfer]){
+		func(e *event.Event[Transfer]) { e.Data.Amount = 1000 },
+		func(e *event.Event[Transfer]) { e.ID = "other" },
+		func(e *event.Event[Transfer]) { e.Priority = 10 },
+		func(e *event.Event[Transfer]) { e.Deadline = e.Deadline.Add(time.Hour) },
+		func(e *event.Event[Transfer]) { event.WithHeader("tenant", "y")(&e.Metadata) },
+		func(e *event.Event[Transfer]) { event.WithHeader(SignatureHeader, "!")(&e.Metadata) },
+		func(e *event.Event[Transfer]) { event.WithHeader(KeyIDHeader, "unknown")(&e.Metadata) },
+		func(e *event.Event[Transfer]) { e.Headers = event.Headers{} },
+	}
+	for i, tamper := range tampered {
+		modified := signed
+		event.WithMetadata(signed.Metadata)(&modified.Metadata)
+		tamper(&modified)
+		if err := signer.Verify(modified); err == nil {
+			t.Errorf("Expected Error When Verifying Tampered Event '%d'", i)
+		}
+	}
+}
+
+func TestSigner_Rotation(t *testing.T) {
+	_, privateKey, _ := ed25519.GenerateKey(nil)
+	producerKeys, _ := CreateKeyring(CreateHMACKey("2024", []byte("old")))
+	producer := CreateSigner[Transfer](producerKeys)
+	consumerKeys, _ := CreateKeyring(CreateHMACKey("2024", []byte("old")))
+	consumer := CreateSigner[Transfer](consumerKeys)
+
+	old, _ := producer.Sign(event.CreateEvent(Transfer{Amount: 1}))
+	_ = producerKeys.Add(CreateEd25519Key("2025", privateKey))
+	if err := producerKeys.Rotate("2025"); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	rotated, _ := producer.Sign(event.CreateEvent(Transfer{Amount: 2}))
+	if value := rotated.Headers.Get(KeyIDHeader); value != "2025" {
+		t.Errorf("Expected Header '%s' To Equal '%s' Actual '%s'", KeyIDHeader, "2025", value)
+	}
+
+	if err := consumer.Verify(rotated); err == nil {
+		t.Errorf("Expected Error When Verifying With Unknown Key")
+	}
+	_ = consumerKeys.Add(CreateEd25519PublicKey("2025", privateKey.Public().(ed25519.PublicKey)))
+	for _, signed := range []event.Event[Transfer]{old, rotated} {
+		if err := consumer.Verify(signed); err != nil {
+			t.Errorf("Unexpected error occured: %s", err.Error())
+		}
+	}
+	consumerKeys.Remove("2024")
+	if err := consumer.Verify(old); err == nil {
+		t.Errorf("Expected Error When Verifying With Removed Key")
+	}
+}
+
+func TestSigner_Types(t *testing.T) {
+	types := codec.CreateRegistry()
+	if err := codec.Register[Transfer](types, "transfer"); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	keyring, _ := CreateKeyring(CreateHMACKey("2024", []byte("secret")))
+	signer := Signer[any]{Keys: keyring, Codec: codec.JSON, Types: types}
+
+	signed, err := signer.Sign(event.CreateEvent[any](Transfer{Amount: 5}))
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := signer.Verify(signed); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	if _, err := signer.Sign(event.CreateEvent[any]("unregistered")); err == nil {
+		t.Errorf("Expected Error When Signing Unregistered Type")
+	}
+}
+
+func TestSigner_ShouldSignHeadersIndependentOfOrder(t *testing.T) {
+	keyring, _ := CreateKeyring(CreateHMACKey("2024", []byte("secret")))
+	options := make([]event.Option, 0)
+	for i := 0; i < 32; i++ {
+		options = append(options, event.WithHeader(fmt.Sprintf("header%d", i), fmt.Sprintf("%d", i)))
+	}
+	for _, c := range []codec.Codec{codec.JSON, codec.CBOR} {
+		signer := Signer[Transfer]{Keys: keyring, Codec: c}
+		signed, err := signer.Sign(event.CreateEvent(Transfer{Amount: 5}, options...))
+		if err != nil {
+			t.Fatalf("Unexpected error occured: %s", err.Error())
+		}
+		for i := 0; i < 20; i++ {
+			received := signed
+			received.Headers = event.CreateHeaders(signed.Headers.ToMap())
+			if err := signer.Verify(received); err != nil {
+				t.Fatalf("Unexpected error occured with codec '%s': %s", c.Name(), err.Error())
+			}
+		}
+	}
+
+	signer := Signer[Transfer]{Keys: keyring, Codec: codec.Gob}
+	if _, err := signer.Sign(event.CreateEvent(Transfer{Amount: 5}, options...)); err == nil {
+		t.Errorf("Expected Error When Signing With Codec '%s'", codec.Gob.Name())
+	}
+}
+
+func TestSigner_Middleware(t *testing.T) {
+	signer := createSigner(t)
+	b := bloc.CreateBloCWithState(struct{}{}, Account{Balance: 100}, func(CurrentState Account, NewEvent event.Event[Transfer], _ *struct{}) Account {
+		return Account{Balance: CurrentState.Balance - NewEvent.Data.Amount}
+	})
+	defer b.Dispose()
+	c := clock.CreateVirtualClock(time.Unix(0, 0))
+	b.SetClock(c)
+	if err := b.SetScheduler(c); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	b.UseEventMiddleware(signer.Middleware())
+	if err := b.StartListenToEventStream(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	signed, _ := signer.Sign(event.CreateEvent(Transfer{Amount: 10}))
+	if err := b.AddEvent(signed.Data, event.WithMetadata(signed.Metadata)); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	if err := b.AddEvent(Transfer{Amount: 90}, event.WithMetadata(signed.Metadata)); err == nil {
+		t.Errorf("Expected Error When Adding Tampered Event")
+	}
+	if err := b.AddEvent(Transfer{Amount: 90}); err == nil {
+		t.Errorf("Expected Error When Adding Unsigned Event")
+	}
+	c.Flush()
+
+	if state, _ := b.GetState(); state.Balance != 90 {
+		t.Errorf("Expected Balance To Equal '%d' Actual '%d'", 90, state.Balance)
+	}
+	if value := len(b.GetEventHistory()); value != 1 {
+		t.Errorf("Expected len(GetEventHistory) To Equal '%d' Actual '%d'", 1, value)
+	}
+}
@@ -49,7 +49,9 @@ func InitStreamBuilder[E any, S any, BD any](BloC bloc.BloC[E, S, BD], InitialEv
 		return streamBuilder, err
 	}
 	err = streamBuilder.BloC.ListenOnNewState(BuildFunc)
-	streamBuilder.BloC.AddEvent(*InitialEvent)
+	if addErr := streamBuilder.BloC.AddEvent(*InitialEvent); err == nil {
+		err = addErr
+	}
 	return streamBuilder, err
 }
 
@@ -64,9 +64,11 @@ func TestInitStreamBuilder(t *testing.T) {
 	}
 
 	wg.Add(1)
-	streamBuilder.BloC.AddEvent(Event{
+	if err := streamBuilder.BloC.AddEvent(Event{
 		Data: 2,
-	})
+	}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
 	wg.Wait()
 	if value != 2 {
 		t.Errorf("Expected check Of Value '%d' Actual '%d'", 2, value)
@@ -98,7 +100,9 @@ func TestStreamBuilder_Dispose(t *testing.T) {
 	}
 
 	streamBuilder.Dispose()
-	streamBuilder.BloC.AddEvent(Event{Data: 2})
+	if err := streamBuilder.BloC.AddEvent(Event{Data: 2}); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
 	time.Sleep(1 * time.Second)
 	if value != 1 {
 		t.Errorf("Expected check Of Value '%d' Actual '%d'", 1, value)
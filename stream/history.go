@@ -0,0 +1,112 @@
+package stream
+
+import (
+	"fmt"
+	"time"
+
+	err "github.com/hijgo/go-bloc/error"
+	"github.com/hijgo/go-bloc/event"
+)
+
+// Returns a copy of all items currently stored in the history, ordered from the oldest to the newest item.
+// Changing the returned slice will not affect the history of the stream.
+func (s *Stream[T]) History() []T {
+	s.historyLock.Lock()
+	defer s.historyLock.Unlock()
+	s.applyRetention()
+
+	items := make([]T, 0, len(s.history))
+	for _, item := range s.history {
+		items = append(items, *item)
+	}
+	return items
+}
+
+// Returns a copy of the items stored in the history between two positions.
+//
+// From : The position of the first item that should be returned (inclusive)
+//
+// To : The position after the last item that should be returned (exclusive)
+//
+// Will return an error when the given range is not inside the history range.
+func (s *Stream[T]) HistoryRange(From int, To int) ([]T, error) {
+	s.historyLock.Lock()
+	defer s.historyLock.Unlock()
+	s.applyRetention()
+
+	if HistoryLength := len(s.history); From < 0 || To > HistoryLength || From > To {
+		return nil, &err.Error{
+			Context: "Wanted range not in range of history",
+			Err:     fmt.Errorf("range '%d:%d' out of range '%d'", From, To, HistoryLength),
+			Code:    err.CodeOutOfRange,
+		}
+	}
+
+	items := make([]T, 0, To-From)
+	for _, item := range s.history[From:To] {
+		items = append(items, *item)
+	}
+	return items, nil
+}
+
+// Returns an iterator over the history, yielding the position and the item, ordered from the oldest to the newest item.
+// The iterator is compatible with iter.Seq2[int, T] and works on a snapshot of the history taken when the
+// iteration starts, so adding new items while iterating is safe.
+func (s *Stream[T]) HistoryItems() func(yield func(int, T) bool) {
+	return func(yield func(int, T) bool) {
+		for i, item := range s.History() {
+			if !yield(i, item) {
+				return
+			}
+		}
+	}
+}
+
+// Returns all events in the history of the given event stream, that were created between From and To (both inclusive),
+// ordered from the oldest to the newest event.
+//
+// E : The type of data carried with by the events of the stream
+//
+// Stream : The event stream whose history should be searched
+//
+// From : The earliest creation time of an event that should be returned
+//
+// To : The latest creation time of an event that should be returned
+func EventsBetween[E any](Stream *Stream[event.Event[E]], From time.Time, To time.Time) []event.Event[E] {
+	events := make([]event.Event[E], 0)
+	for _, e := range Stream.History() {
+		if createdBetween(e, From, To) {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// Returns the first event in the history of the given event stream that was created at the given time.
+// If no such event exists, will return false as second value.
+//
+// E : The type of data carried with by the events of the stream
+//
+// Stream : The event stream whose history should be searched
+//
+// TimeStamp : The creation time of the wanted event
+func FindEventByTime[E any](Stream *Stream[event.Event[E]], TimeStamp time.Time) (event.Event[E], bool) {
+	for _, e := range Stream.History() {
+		if createdBetween(e, TimeStamp, TimeStamp) {
+			return e, true
+		}
+	}
+	return event.Event[E]{}, false
+}
+
+// Compares events created without Time by their TimeStamp in milliseconds.
+func createdBetween[E any](Event event.Event[E], From time.Time, To time.Time) bool {
+	if Event.Time.IsZero() {
+		return Event.TimeStamp >= toTimeStamp(From) && Event.TimeStamp <= toTimeStamp(To)
+	}
+	return !Event.Time.Before(From) && !Event.Time.After(To)
+}
+
+func toTimeStamp(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
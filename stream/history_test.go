@@ -0,0 +1,148 @@
+package stream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/event"
+)
+
+func TestStream_History(t *testing.T) {
+	s := CreateStream(2, func(int) {})
+	s.Add(1)
+	s.Add(2)
+	s.Add(3)
+
+	history := s.History()
+	if value := len(history); value != 2 {
+		t.Fatalf("Expected len(History) To Equal '%d' Actual '%d'", 2, value)
+	}
+	for i, expected := range []int{2, 3} {
+		if value := history[i]; value != expected {
+			t.Errorf("Expected History To Equal '%d' At Position '%d' Actual '%d'", expected, i, value)
+		}
+	}
+
+	history[0] = 10
+	if value := *s.history[0]; value != 2 {
+		t.Errorf("Expected history To Be Unchanged With '%d' At Position '%d' Actual '%d'", 2, 0, value)
+	}
+}
+
+func TestStream_HistoryRange(t *testing.T) {
+	s := CreateStream(5, func(int) {})
+	for i := 0; i < 5; i++ {
+		s.Add(i)
+	}
+
+	items, err := s.HistoryRange(1, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	for i, expected := range []int{1, 2} {
+		if value := items[i]; value != expected {
+			t.Errorf("Expected HistoryRange To Equal '%d' At Position '%d' Actual '%d'", expected, i, value)
+		}
+	}
+
+	items, err = s.HistoryRange(5, 5)
+	if err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if value := len(items); value != 0 {
+		t.Errorf("Expected len(HistoryRange) To Equal '%d' Actual '%d'", 0, value)
+	}
+}
+
+func TestStream_HistoryRangeShouldReturnErrorWhenRangeNotInHistory(t *testing.T) {
+	s := CreateStream(5, func(int) {})
+	s.Add(1)
+
+	for _, r := range [][2]int{{-1, 1}, {0, 2}, {1, 0}} {
+		_, err := s.HistoryRange(r[0], r[1])
+		if err == nil {
+			t.Errorf("Expected HistoryRange To Return Error For Range '%d:%d'", r[0], r[1])
+		}
+	}
+}
+
+func TestStream_HistoryItems(t *testing.T) {
+	s := CreateStream(5, func(int) {})
+	for i := 0; i < 5; i++ {
+		s.Add(i * 10)
+	}
+
+	visited := 0
+	s.HistoryItems()(func(Position int, Item int) bool {
+		if Item != Position*10 {
+			t.Errorf("Expected Item To Equal '%d' At Position '%d' Actual '%d'", Position*10, Position, Item)
+		}
+		visited++
+		return Position < 2
+	})
+	if visited != 3 {
+		t.Errorf("Expected HistoryItems To Stop After '%d' Items Actual '%d'", 3, visited)
+	}
+}
+
+func TestEventsBetween(t *testing.T) {
+	s := CreateStream(5, func(event.Event[int]) {})
+	start := time.UnixMilli(1000)
+	for i := 0; i < 5; i++ {
+		s.Add(event.Event[int]{TimeStamp: start.Add(time.Duration(i) * time.Second).UnixMilli(), Data: i})
+	}
+
+	events := EventsBetween(&s, start.Add(time.Second), start.Add(3*time.Second))
+	if value := len(events); value != 3 {
+		t.Fatalf("Expected len(EventsBetween) To Equal '%d' Actual '%d'", 3, value)
+	}
+	for i, expected := range []int{1, 2, 3} {
+		if value := events[i].Data; value != expected {
+			t.Errorf("Expected EventsBetween To Equal '%d' At Position '%d' Actual '%d'", expected, i, value)
+		}
+	}
+}
+
+func TestFindEventByTime(t *testing.T) {
+	s := CreateStream(5, func(event.Event[int]) {})
+	s.Add(event.Event[int]{TimeStamp: 1000, Data: 1})
+	s.Add(event.Event[int]{TimeStamp: 2000, Data: 2})
+
+	e, found := FindEventByTime(&s, time.UnixMilli(2000))
+	if !found {
+		t.Fatalf("Expected FindEventByTime To Find Event")
+	}
+	if value := e.Data; value != 2 {
+		t.Errorf("Expected Data To Equal '%d' Actual '%d'", 2, value)
+	}
+
+	if _, found := FindEventByTime(&s, time.UnixMilli(3000)); found {
+		t.Errorf("Expected FindEventByTime To Not Find Event")
+	}
+}
+
+func TestStream_AddShouldAssignSequence(t *testing.T) {
+	s := CreateStream(3, func(event.Event[int]) {})
+	start := time.Unix(0, 0)
+	for i := 1; i <= 5; i++ {
+		s.Add(event.CreateEventAt(i, start.Add(time.Duration(i)*time.Microsecond)))
+	}
+
+	history := s.History()
+	for i, e := range history {
+		if value := e.Sequence; value != uint64(i+3) {
+			t.Errorf("Expected Sequence To Equal '%d' At Position '%d' Actual '%d'", i+3, i, value)
+		}
+	}
+	if value := s.GetSequence(); value != 5 {
+		t.Errorf("Expected GetSequence To Equal '%d' Actual '%d'", 5, value)
+	}
+
+	between := EventsBetween(&s, start.Add(3*time.Microsecond), start.Add(4*time.Microsecond))
+	if value := len(between); value != 2 || between[0].Data != 3 {
+		t.Errorf("Expected EventsBetween To Distinguish Events Within The Same Millisecond Actual '%v'", between)
+	}
+	if e, found := FindEventByTime(&s, start.Add(5*time.Microsecond)); !found || e.Data != 5 {
+		t.Errorf("Expected FindEventByTime To Find Event '%d' Actual '%v'", 5, e)
+	}
+}
@@ -0,0 +1,154 @@
+package stream
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	err "github.com/hijgo/go-bloc/error"
+)
+
+// Items providing a priority, like event.Event, are delivered before pending items with a lower priority.
+type prioritized interface {
+	GetPriority() int
+}
+
+// Items that can expire, like event.Event, are dropped instead of being delivered once they expired.
+type expiring interface {
+	IsExpired(Now time.Time) bool
+}
+
+type lane[T any] struct {
+	priority int
+	items    []T
+}
+
+// Queue of items waiting to be delivered to OnNewItem, one FIFO lane per priority.
+type lanes[T any] struct {
+	lock  sync.Mutex
+	lanes []*lane[T]
+}
+
+func (l *lanes[T]) push(Item T) {
+	priority := 0
+	if item, ok := any(Item).(prioritized); ok {
+		priority = item.GetPriority()
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	index := sort.Search(len(l.lanes), func(i int) bool { return l.lanes[i].priority <= priority })
+	if index == len(l.lanes) || l.lanes[index].priority != priority {
+		l.lanes = append(l.lanes, nil)
+		copy(l.lanes[index+1:], l.lanes[index:])
+		l.lanes[index] = &lane[T]{priority: priority}
+	}
+	l.lanes[index].items = append(l.lanes[index].items, Item)
+}
+
+func (l *lanes[T]) pop() (T, bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for _, current := range l.lanes {
+		if len(current.items) == 0 {
+			continue
+		}
+		item := current.items[0]
+		var empty T
+		current.items[0] = empty
+		current.items = current.items[1:]
+		return item, true
+	}
+	var empty T
+	return empty, false
+}
+
+func (l *lanes[T]) size() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	size := 0
+	for _, current := range l.lanes {
+		size += len(current.items)
+	}
+	return size
+}
+
+// Queues items passed into the stream instead of handing them to the listener one by one. Pending items are processed
+// by priority, items of the same priority in the order they were passed into the stream. Items providing a GetPriority
+// method, like event.Event, are put into the lane of their priority, all other items into lane 0. Unlike without
+// lanes, Add returns without waiting for the item to be taken by the listener, items passed into the stream before
+// StopListen are still processed.
+//
+// Will return an error if the stream is currently listened to.
+func (s *Stream[T]) UsePriorityLanes() error {
+	if s.isListenedTo {
+		return &err.Error{
+			Context: "Cannot use priority lanes, stream is listened to!",
+			Err:     fmt.Errorf("stream already listened to"),
+			Code:    err.CodeAlreadyListening,
+		}
+	}
+	s.usesLanes = true
+	return nil
+}
+
+// Returns true if Add waits until the listener took the new item, false if a scheduler or priority lanes are used.
+// Calling Add from inside OnNewItem while Add blocks would wait forever.
+func (s *Stream[_]) AddBlocks() bool {
+	return s.scheduler == nil && !s.usesLanes
+}
+
+// Returns the number of items passed into the stream that were not delivered to OnNewItem yet.
+func (s *Stream[_]) GetPendingSize() int {
+	return s.queue.size()
+}
+
+func (s *Stream[T]) enqueue(NewItem T) {
+	s.queue.push(NewItem)
+	if s.scheduler != nil {
+		s.scheduler.Schedule(func() {
+			if item, ok := s.next(); ok && s.isListenedTo {
+				s.OnNewItem(item)
+			}
+		})
+		return
+	}
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Returns true and calls OnExpiredItem if the item expired.
+func (s *Stream[T]) expired(Item T) bool {
+	expirable, ok := any(Item).(expiring)
+	if !ok || !expirable.IsExpired(s.now()) {
+		return false
+	}
+	if s.OnExpiredItem != nil {
+		s.OnExpiredItem(Item)
+	}
+	return true
+}
+
+// Returns the next pending item that has not expired, dropping expired items on the way.
+func (s *Stream[T]) next() (T, bool) {
+	for {
+		item, ok := s.queue.pop()
+		if !ok || !s.expired(item) {
+			return item, ok
+		}
+	}
+}
+
+// Delivers all pending items to OnNewItem.
+func (s *Stream[T]) drain() {
+	for {
+		item, ok := s.next()
+		if !ok {
+			return
+		}
+		s.OnNewItem(item)
+	}
+}
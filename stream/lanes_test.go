@@ -0,0 +1,135 @@
+package stream
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/clock"
+	"github.com/hijgo/go-bloc/event"
+)
+
+func TestStream_UsePriorityLanes(t *testing.T) {
+	v := clock.CreateVirtualClock(time.Unix(0, 0))
+	received := make([]string, 0)
+	expired := make([]string, 0)
+	s := CreateStream(10, func(NewItem event.Event[string]) { received = append(received, NewItem.Data) })
+	s.OnExpiredItem = func(ExpiredItem event.Event[string]) { expired = append(expired, ExpiredItem.Data) }
+	s.SetClock(v)
+	if err := s.SetScheduler(v); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := s.UsePriorityLanes(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := s.Listen(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := s.UsePriorityLanes(); err == nil {
+		t.Errorf("Expected Error When Using Priority Lanes On Listened Stream")
+	}
+
+	s.Add(event.CreateEventAt("typing", v.Now().Add(-time.Second), event.WithTTL(time.Second)))
+	s.Add(event.CreateEventAt("a", v.Now()))
+	s.Add(event.CreateEventAt("low", v.Now(), event.WithPriority(-1)))
+	s.Add(event.CreateEventAt("cancel", v.Now(), event.WithPriority(10)))
+	s.Add(event.CreateEventAt("b", v.Now()))
+	s.Add(event.CreateEventAt("urgent", v.Now(), event.WithPriority(10), event.WithDeadline(v.Now().Add(time.Minute))))
+	if value := s.GetPendingSize(); value != 6 {
+		t.Errorf("Expected GetPendingSize To Equal '%d' Actual '%d'", 6, value)
+	}
+
+	v.Flush()
+
+	if expected := []string{"cancel", "urgent", "a", "b", "low"}; !reflect.DeepEqual(received, expected) {
+		t.Errorf("Expected received To Equal '%v' Actual '%v'", expected, received)
+	}
+	if expected := []string{"typing"}; !reflect.DeepEqual(expired, expected) {
+		t.Errorf("Expected expired To Equal '%v' Actual '%v'", expected, expired)
+	}
+	if value := s.GetPendingSize(); value != 0 {
+		t.Errorf("Expected GetPendingSize To Equal '%d' Actual '%d'", 0, value)
+	}
+	if value := s.GetHistorySize(); value != 6 {
+		t.Errorf("Expected GetHistorySize To Equal '%d' Actual '%d'", 6, value)
+	}
+}
+
+func TestStream_UsePriorityLanes_Goroutine(t *testing.T) {
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	received := make([]int, 0)
+	s := CreateStream(UnlimitedHistorySize, func(NewItem int) {
+		lock.Lock()
+		received = append(received, NewItem)
+		lock.Unlock()
+		wg.Done()
+	})
+	if err := s.UsePriorityLanes(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := s.Listen(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	wg.Add(100)
+	for i := 0; i < 100; i++ {
+		s.Add(i)
+	}
+	if err := s.StopListen(); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	wg.Wait()
+
+	lock.Lock()
+	defer lock.Unlock()
+	for i, value := range received {
+		if value != i {
+			t.Fatalf("Expected Item To Equal '%d' At Position '%d' Actual '%d'", i, i, value)
+		}
+	}
+}
+
+func TestStream_AddBlocks(t *testing.T) {
+	s := CreateStream(0, func(int) {})
+	if value := s.AddBlocks(); !value {
+		t.Errorf("Expected AddBlocks To Equal '%t' Actual '%t'", true, value)
+	}
+	if err := s.UsePriorityLanes(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if value := s.AddBlocks(); value {
+		t.Errorf("Expected AddBlocks To Equal '%t' With Priority Lanes Actual '%t'", false, value)
+	}
+
+	scheduled := CreateStream(0, func(int) {})
+	if err := scheduled.SetScheduler(clock.CreateVirtualClock(time.Unix(0, 0))); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if value := scheduled.AddBlocks(); value {
+		t.Errorf("Expected AddBlocks To Equal '%t' With Scheduler Actual '%t'", false, value)
+	}
+}
+
+func TestStream_DropExpiredItems(t *testing.T) {
+	v := clock.CreateVirtualClock(time.Unix(0, 0))
+	received := make([]string, 0)
+	s := CreateStream(10, func(NewItem event.Event[string]) { received = append(received, NewItem.Data) })
+	s.SetClock(v)
+	if err := s.SetScheduler(v); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := s.Listen(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+
+	s.Add(event.CreateEventAt("expired", v.Now(), event.WithDeadline(v.Now())))
+	s.Add(event.CreateEventAt("high", v.Now(), event.WithPriority(1)))
+	s.Add(event.CreateEventAt("valid", v.Now(), event.WithTTL(time.Second)))
+	v.Flush()
+
+	if expected := []string{"high", "valid"}; !reflect.DeepEqual(received, expected) {
+		t.Errorf("Expected received To Equal '%v' Actual '%v'", expected, received)
+	}
+}
@@ -0,0 +1,169 @@
+package stream
+
+import (
+	"math"
+	"time"
+
+	"github.com/hijgo/go-bloc/event"
+)
+
+// Can be used as MaxHistorySize, when the number of items in the history should not be limited.
+// The history will then only be limited by the retention policies of the stream. Zero or negative sizes keep no history.
+const UnlimitedHistorySize = math.MaxInt
+
+// A single item of the history together with the time it was added to the stream.
+//
+// T : Type of the data that will be processed
+//
+// Item : The item stored in the history
+//
+// InsertedAt : The time the item was added to the history
+type HistoryEntry[T any] struct {
+	Item       T
+	InsertedAt time.Time
+}
+
+// A function that decides which entries of the history should be kept. Will be called with all entries of the history,
+// ordered from the oldest to the newest entry, and must return the entries that should be kept in the same order.
+// Every entry not returned will be evicted from the history.
+//
+// T : Type of the data that will be processed
+//
+// Entries : The entries currently stored in the history
+//
+// Now : The current time, should be used for any time based decision
+type RetentionPolicy[T any] func(Entries []HistoryEntry[T], Now time.Time) []HistoryEntry[T]
+
+// Adds a new retention policy to the stream. The policy will be applied every time a new item is added to the history and
+// every time the history is read, together with MaxHistorySize and all previously added policies.
+//
+// Policy : The retention policy that should be applied to the history
+func (s *Stream[T]) AddRetentionPolicy(Policy RetentionPolicy[T]) {
+	s.historyLock.Lock()
+	defer s.historyLock.Unlock()
+	s.retentionPolicies = append(s.retentionPolicies, Policy)
+	s.applyRetention()
+}
+
+// Changes the capacity of the history. If the history currently holds more items than allowed, the oldest items will
+// be evicted.
+//
+// MaxHistorySize : The new capacity of the history, use UnlimitedHistorySize to not limit the number of items
+func (s *Stream[T]) SetMaxHistorySize(MaxHistorySize int) {
+	s.historyLock.Lock()
+	defer s.historyLock.Unlock()
+	s.MaxHistorySize = MaxHistorySize
+	s.noHistory = !(MaxHistorySize > 0)
+	if s.noHistory {
+		s.history = s.history[:0]
+		s.historyInsertedAt = s.historyInsertedAt[:0]
+	}
+	s.applyRetention()
+}
+
+// Evicts all items of the history not satisfying MaxHistorySize or one of the retention policies.
+// Must be called while holding the write lock of the history.
+func (s *Stream[T]) applyRetention() {
+	if s.MaxHistorySize > 0 && len(s.history) > s.MaxHistorySize {
+		evict := len(s.history) - s.MaxHistorySize
+		s.history = s.history[evict:]
+		s.historyInsertedAt = s.historyInsertedAt[evict:]
+	}
+
+	if len(s.retentionPolicies) == 0 || len(s.history) == 0 {
+		return
+	}
+
+	entries := make([]HistoryEntry[T], 0, len(s.history))
+	for i, item := range s.history {
+		entries = append(entries, HistoryEntry[T]{Item: *item, InsertedAt: s.historyInsertedAt[i]})
+	}
+	now := s.now()
+	for _, policy := range s.retentionPolicies {
+		entries = policy(entries, now)
+	}
+	if len(entries) == len(s.history) {
+		return
+	}
+
+	s.history = make([]*T, 0, len(entries))
+	s.historyInsertedAt = make([]time.Time, 0, len(entries))
+	for i := range entries {
+		s.history = append(s.history, &entries[i].Item)
+		s.historyInsertedAt = append(s.historyInsertedAt, entries[i].InsertedAt)
+	}
+}
+
+// Returns a retention policy that only keeps the newest Count items.
+//
+// Count : The maximum number of items kept in the history
+func MaxItems[T any](Count int) RetentionPolicy[T] {
+	return func(Entries []HistoryEntry[T], Now time.Time) []HistoryEntry[T] {
+		if len(Entries) <= Count {
+			return Entries
+		}
+		return Entries[len(Entries)-Count:]
+	}
+}
+
+// Returns a retention policy that evicts every item that was added to the history longer than Age ago.
+//
+// Age : The maximum time an item is kept in the history
+func MaxAge[T any](Age time.Duration) RetentionPolicy[T] {
+	return MaxAgeBy(Age, func(Entry HistoryEntry[T]) time.Time { return Entry.InsertedAt })
+}
+
+// Returns a retention policy that evicts every item older than Age, using the time returned by TimeOf as the age of an item.
+//
+// Age : The maximum age of an item kept in the history
+//
+// TimeOf : Function that returns the time an entry should be considered created at
+func MaxAgeBy[T any](Age time.Duration, TimeOf func(Entry HistoryEntry[T]) time.Time) RetentionPolicy[T] {
+	return RetainWhere(func(Entry HistoryEntry[T], Now time.Time) bool {
+		return Now.Sub(TimeOf(Entry)) <= Age
+	})
+}
+
+// Returns a retention policy for event streams, that evicts every event created longer than Age ago.
+//
+// E : The type of data carried with by the events of the stream
+//
+// Age : The maximum age of an event kept in the history
+func MaxEventAge[E any](Age time.Duration) RetentionPolicy[event.Event[E]] {
+	return MaxAgeBy(Age, func(Entry HistoryEntry[event.Event[E]]) time.Time {
+		return Entry.Item.CreatedAt()
+	})
+}
+
+// Returns a retention policy that evicts the oldest items, until the summed up size of all items is at most Bytes.
+//
+// Bytes : The maximum size of all items kept in the history
+//
+// SizeOf : Function that returns the size of a single item in bytes
+func MaxBytes[T any](Bytes int, SizeOf func(Item T) int) RetentionPolicy[T] {
+	return func(Entries []HistoryEntry[T], Now time.Time) []HistoryEntry[T] {
+		size := 0
+		for i := len(Entries) - 1; i >= 0; i-- {
+			size += SizeOf(Entries[i].Item)
+			if size > Bytes {
+				return Entries[i+1:]
+			}
+		}
+		return Entries
+	}
+}
+
+// Returns a retention policy that only keeps items for which the given predicate returns true.
+//
+// Keep : Function that decides if an entry should be kept in the history
+func RetainWhere[T any](Keep func(Entry HistoryEntry[T], Now time.Time) bool) RetentionPolicy[T] {
+	return func(Entries []HistoryEntry[T], Now time.Time) []HistoryEntry[T] {
+		kept := make([]HistoryEntry[T], 0, len(Entries))
+		for _, entry := range Entries {
+			if Keep(entry, Now) {
+				kept = append(kept, entry)
+			}
+		}
+		return kept
+	}
+}
@@ -0,0 +1,149 @@
+package stream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/event"
+)
+
+func TestStream_UnlimitedHistorySize(t *testing.T) {
+	s := CreateStream(UnlimitedHistorySize, func(int) {})
+	for i := 0; i < 200; i++ {
+		s.Add(i)
+	}
+
+	if value := s.GetHistorySize(); value != 200 {
+		t.Errorf("Expected GetHistorySize To Equal '%d' Actual '%d'", 200, value)
+	}
+}
+
+func TestStream_NegativeHistorySizeShouldKeepNoHistory(t *testing.T) {
+	s := CreateStream(-1, func(int) {})
+	s.Add(1)
+	if value := s.GetHistorySize(); value != 0 {
+		t.Errorf("Expected GetHistorySize To Equal '%d' Actual '%d'", 0, value)
+	}
+
+	s.SetMaxHistorySize(UnlimitedHistorySize)
+	s.Add(2)
+	s.SetMaxHistorySize(-5)
+	s.Add(3)
+	if value := s.GetHistorySize(); value != 0 {
+		t.Errorf("Expected GetHistorySize To Equal '%d' Actual '%d'", 0, value)
+	}
+}
+
+func TestStream_SetMaxHistorySize(t *testing.T) {
+	s := CreateStream(5, func(int) {})
+	for i := 0; i < 5; i++ {
+		s.Add(i)
+	}
+
+	s.SetMaxHistorySize(2)
+	if value := s.GetHistorySize(); value != 2 {
+		t.Errorf("Expected GetHistorySize To Equal '%d' Actual '%d'", 2, value)
+	}
+	if value := s.History()[0]; value != 3 {
+		t.Errorf("Expected History To Equal '%d' At Position '%d' Actual '%d'", 3, 0, value)
+	}
+
+	s.SetMaxHistorySize(0)
+	s.Add(5)
+	if value := s.GetHistorySize(); value != 0 {
+		t.Errorf("Expected GetHistorySize To Equal '%d' Actual '%d'", 0, value)
+	}
+}
+
+func TestStream_AddRetentionPolicy(t *testing.T) {
+	s := CreateStream(UnlimitedHistorySize, func(int) {})
+	s.AddRetentionPolicy(MaxItems[int](3))
+	for i := 0; i < 5; i++ {
+		s.Add(i)
+	}
+
+	history := s.History()
+	if value := len(history); value != 3 {
+		t.Fatalf("Expected len(History) To Equal '%d' Actual '%d'", 3, value)
+	}
+	if value := history[0]; value != 2 {
+		t.Errorf("Expected History To Equal '%d' At Position '%d' Actual '%d'", 2, 0, value)
+	}
+}
+
+func TestMaxAge(t *testing.T) {
+	now := time.Now()
+	entries := []HistoryEntry[int]{
+		{Item: 1, InsertedAt: now.Add(-2 * time.Hour)},
+		{Item: 2, InsertedAt: now.Add(-30 * time.Minute)},
+		{Item: 3, InsertedAt: now},
+	}
+
+	kept := MaxAge[int](time.Hour)(entries, now)
+	if value := len(kept); value != 2 {
+		t.Fatalf("Expected len(kept) To Equal '%d' Actual '%d'", 2, value)
+	}
+	if value := kept[0].Item; value != 2 {
+		t.Errorf("Expected Item To Equal '%d' Actual '%d'", 2, value)
+	}
+}
+
+func TestStream_MaxAgeEvictsOnRead(t *testing.T) {
+	s := CreateStream(UnlimitedHistorySize, func(int) {})
+	s.AddRetentionPolicy(MaxAge[int](20 * time.Millisecond))
+	s.Add(1)
+
+	if value := s.GetHistorySize(); value != 1 {
+		t.Errorf("Expected GetHistorySize To Equal '%d' Actual '%d'", 1, value)
+	}
+	time.Sleep(40 * time.Millisecond)
+	if value := s.GetHistorySize(); value != 0 {
+		t.Errorf("Expected GetHistorySize To Equal '%d' Actual '%d'", 0, value)
+	}
+}
+
+func TestMaxEventAge(t *testing.T) {
+	now := time.Now()
+	entries := []HistoryEntry[event.Event[int]]{
+		{Item: event.Event[int]{TimeStamp: now.Add(-25 * time.Hour).UnixMilli(), Data: 1}, InsertedAt: now},
+		{Item: event.Event[int]{TimeStamp: now.Add(-time.Hour).UnixMilli(), Data: 2}, InsertedAt: now},
+	}
+
+	kept := MaxEventAge[int](24*time.Hour)(entries, now)
+	if value := len(kept); value != 1 {
+		t.Fatalf("Expected len(kept) To Equal '%d' Actual '%d'", 1, value)
+	}
+	if value := kept[0].Item.Data; value != 2 {
+		t.Errorf("Expected Data To Equal '%d' Actual '%d'", 2, value)
+	}
+}
+
+func TestMaxBytes(t *testing.T) {
+	s := CreateStream(UnlimitedHistorySize, func(string) {})
+	s.AddRetentionPolicy(MaxBytes(10, func(Item string) int { return len(Item) }))
+	s.Add("aaaa")
+	s.Add("bbbb")
+	s.Add("cccc")
+
+	history := s.History()
+	if value := len(history); value != 2 {
+		t.Fatalf("Expected len(History) To Equal '%d' Actual '%d'", 2, value)
+	}
+	if value := history[0]; value != "bbbb" {
+		t.Errorf("Expected History To Equal '%s' At Position '%d' Actual '%s'", "bbbb", 0, value)
+	}
+}
+
+func TestRetainWhere(t *testing.T) {
+	s := CreateStream(UnlimitedHistorySize, func(int) {})
+	s.AddRetentionPolicy(RetainWhere(func(Entry HistoryEntry[int], Now time.Time) bool { return Entry.Item%2 == 0 }))
+	for i := 0; i < 6; i++ {
+		s.Add(i)
+	}
+
+	for i, expected := range []int{0, 2, 4} {
+		if value := s.History()[i]; value != expected {
+			t.Errorf("Expected History To Equal '%d' At Position '%d' Actual '%d'", expected, i, value)
+		}
+	}
+}
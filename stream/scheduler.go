@@ -0,0 +1,50 @@
+package stream
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hijgo/go-bloc/clock"
+	err "github.com/hijgo/go-bloc/error"
+)
+
+// Replaces the clock used to timestamp the history and to apply retention policies.
+//
+// Clock : The clock that should be used, clock.Real by default
+func (s *Stream[T]) SetClock(Clock clock.Clock) {
+	s.historyLock.Lock()
+	defer s.historyLock.Unlock()
+	s.clock = Clock
+}
+
+// Replaces the goroutine delivering new items to OnNewItem with the given scheduler. Every item passed into the stream
+// while it is listened to will be handed to the scheduler, for example a clock.Virtual to deliver items
+// deterministically by calling Flush. Pass nil to deliver items from a goroutine again.
+//
+// Scheduler : The scheduler that should deliver new items
+//
+// Will return an error if the stream is currently listened to.
+func (s *Stream[T]) SetScheduler(Scheduler clock.Scheduler) error {
+	if s.isListenedTo {
+		return &err.Error{
+			Context: "Cannot change scheduler, stream is listened to!",
+			Err:     fmt.Errorf("stream already listened to"),
+			Code:    err.CodeAlreadyListening,
+		}
+	}
+	s.scheduler = Scheduler
+	return nil
+}
+
+func (s *Stream[T]) now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock.Now()
+}
+
+func (s *Stream[T]) pause(IsPaused bool) {
+	if s.scheduler == nil {
+		s.pauseListen <- IsPaused
+	}
+}
@@ -0,0 +1,60 @@
+package stream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hijgo/go-bloc/clock"
+)
+
+func TestStream_SetScheduler(t *testing.T) {
+	v := clock.CreateVirtualClock(time.Unix(0, 0))
+	received := make([]int, 0)
+	s := CreateStream(10, func(NewItem int) { received = append(received, NewItem) })
+	s.SetClock(v)
+	if err := s.SetScheduler(v); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := s.Listen(); err != nil {
+		t.Fatalf("Unexpected error occured: %s", err.Error())
+	}
+	if err := s.SetScheduler(nil); err == nil {
+		t.Errorf("Expected Error When Changing Scheduler Of Listened Stream")
+	}
+
+	s.Add(1)
+	s.Add(2)
+	if value := len(received); value != 0 {
+		t.Errorf("Expected len(received) Before Flush To Be Of Value '%d' Actual '%d'", 0, value)
+	}
+	v.Flush()
+	if value := len(received); value != 2 || received[0] != 1 || received[1] != 2 {
+		t.Errorf("Expected received To Equal '%v' Actual '%v'", []int{1, 2}, received)
+	}
+
+	if err := s.StopListen(); err != nil {
+		t.Errorf("Unexpected error occured: %s", err.Error())
+	}
+	s.Add(3)
+	v.Flush()
+	if value := len(received); value != 2 {
+		t.Errorf("Expected len(received) To Be Of Value '%d' Actual '%d'", 2, value)
+	}
+	s.Dispose()
+}
+
+func TestStream_SetClock(t *testing.T) {
+	v := clock.CreateVirtualClock(time.Unix(0, 0))
+	s := CreateStream(UnlimitedHistorySize, func(NewItem int) {})
+	s.SetClock(v)
+	s.AddRetentionPolicy(MaxAge[int](time.Minute))
+
+	s.Add(1)
+	v.Advance(30 * time.Second)
+	s.Add(2)
+	v.Advance(45 * time.Second)
+
+	if value := s.GetHistorySize(); value != 1 {
+		t.Errorf("Expected GetHistorySize To Be Of Value '%d' Actual '%d'", 1, value)
+	}
+}
@@ -3,7 +3,9 @@ package stream
 import (
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/hijgo/go-bloc/clock"
 	err "github.com/hijgo/go-bloc/error"
 )
 
@@ -13,19 +15,32 @@ import (
 //
 // T : Type of the data that will be processed
 //
-// MaxHistorySize : The capacity of the history being saved
+// MaxHistorySize : The capacity of the history being saved, use UnlimitedHistorySize to not limit the number of items
 //
 // OnNewItem : A Function that will be called everytime a new item is being passed to the stream
+//
+// OnExpiredItem : An optional Function that will be called for every item that expired before it could be passed to
+// OnNewItem
 type Stream[T any] struct {
 	MaxHistorySize                    int
 	OnNewItem                         func(NewItem T)
+	OnExpiredItem                     func(ExpiredItem T)
 	sink                              chan T
+	queue                             lanes[T]
+	usesLanes                         bool
+	ready                             chan struct{}
 	isListenedTo                      bool
 	pauseListen                       chan bool
 	stopListen                        chan struct{}
 	history                           []*T
+	historyInsertedAt                 []time.Time
+	retentionPolicies                 []RetentionPolicy[T]
 	wasDisposed                       bool
 	noHistory                         bool
+	historyLock                       sync.RWMutex
+	clock                             clock.Clock
+	scheduler                         clock.Scheduler
+	sequence                          uint64
 	waitForResumeAtPositionCompletion sync.WaitGroup
 }
 
@@ -34,18 +49,24 @@ type Stream[T any] struct {
 //
 // T : Type of the data that will be processed
 //
-// MaxHistorySize : The capacity of the history being saved
+// MaxHistorySize : The capacity of the history being saved, use UnlimitedHistorySize to not limit the number of items
 //
 // OnNewItem : A Function that will be called everytime a new item is being passed to the stream
 func CreateStream[T any](MaxHistorySize int, OnNewItem func(NewItem T)) Stream[T] {
+	historyCapacity := 0
+	if MaxHistorySize > 0 && MaxHistorySize != UnlimitedHistorySize {
+		historyCapacity = MaxHistorySize
+	}
 	return Stream[T]{
-		MaxHistorySize: MaxHistorySize,
-		OnNewItem:      OnNewItem,
-		sink:           make(chan T),
-		pauseListen:    make(chan bool),
-		stopListen:     make(chan struct{}),
-		history:        make([]*T, 0, MaxHistorySize),
-		noHistory:      !(MaxHistorySize > 0),
+		MaxHistorySize:    MaxHistorySize,
+		OnNewItem:         OnNewItem,
+		sink:              make(chan T),
+		ready:             make(chan struct{}, 1),
+		pauseListen:       make(chan bool),
+		stopListen:        make(chan struct{}),
+		history:           make([]*T, 0, historyCapacity),
+		historyInsertedAt: make([]time.Time, 0, historyCapacity),
+		noHistory:         !(MaxHistorySize > 0),
 	}
 }
 
@@ -65,9 +86,13 @@ func (s *Stream[_]) StopListen() error {
 		return &err.Error{
 			Context: "Cannot call stop listening when stream isn't listened to!",
 			Err:     fmt.Errorf("stream isn't listened to"),
+			Code:    err.CodeNotListening,
 		}
 	}
 	s.isListenedTo = false
+	if s.scheduler != nil {
+		return nil
+	}
 	defer func() { s.stopListen <- struct{}{} }()
 
 	return nil
@@ -75,25 +100,35 @@ func (s *Stream[_]) StopListen() error {
 
 // Called to start listening to a stream of items. If the stream is already listened to, will return an error.
 // Else will set the listening status to true and start processing new items with the OnNewItem function.
+// Expired items are dropped instead of being processed, see UsePriorityLanes to process items by priority.
 func (s *Stream[T]) Listen() error {
 	if s.isListenedTo {
 		return &err.Error{
 			Context: "Cannot listen to stream, already being listened to!",
 			Err:     fmt.Errorf("stream already listened to"),
+			Code:    err.CodeAlreadyListening,
 		}
 	} else if s.wasDisposed {
 		return &err.Error{
 			Context: "Cannot listen to stream, stream was disposed!",
 			Err:     fmt.Errorf("stream was disposed"),
+			Code:    err.CodeDisposed,
 		}
 	}
 
 	s.isListenedTo = true
+	if s.scheduler != nil {
+		return nil
+	}
 	go func() {
 		for {
 			select {
 			case newItem := <-s.sink:
-				s.OnNewItem(newItem)
+				if !s.expired(newItem) {
+					s.OnNewItem(newItem)
+				}
+			case <-s.ready:
+				s.drain()
 			case isPaused := <-s.pauseListen:
 				if isPaused {
 					var wg sync.WaitGroup
@@ -108,6 +143,7 @@ func (s *Stream[T]) Listen() error {
 					wg.Wait()
 				}
 			case <-s.stopListen:
+				s.drain()
 				return
 			}
 		}
@@ -115,8 +151,18 @@ func (s *Stream[T]) Listen() error {
 	return nil
 }
 
+// Returns the sequence number assigned to the last item passed into the stream, 0 if no item was passed yet.
+func (s *Stream[_]) GetSequence() uint64 {
+	s.historyLock.RLock()
+	defer s.historyLock.RUnlock()
+	return s.sequence
+}
+
 // Returning the current length of the history.
 func (s *Stream[_]) GetHistorySize() int {
+	s.historyLock.Lock()
+	defer s.historyLock.Unlock()
+	s.applyRetention()
 	return len(s.history)
 }
 
@@ -130,24 +176,34 @@ func (s *Stream[_]) GetHistorySize() int {
 func (s *Stream[T]) ResumeAtHistoryPosition(Position int) error {
 	s.waitForResumeAtPositionCompletion.Wait()
 	s.waitForResumeAtPositionCompletion.Add(1)
-	s.pauseListen <- true
+	s.pause(true)
 
-	if HistoryLength := len(s.history); Position < 0 || Position > HistoryLength || HistoryLength == 0 {
+	s.historyLock.RLock()
+	HistoryLength := len(s.history)
+	s.historyLock.RUnlock()
+	if Position < 0 || Position > HistoryLength || HistoryLength == 0 {
 		defer func() {
-			s.pauseListen <- false
+			s.pause(false)
 			s.waitForResumeAtPositionCompletion.Done()
 		}()
 		return &err.Error{
 			Context: "Wanted Position not in range of history",
-			Err:     fmt.Errorf("position '%d' out of range '%d'", Position, len(s.history)),
+			Err:     fmt.Errorf("position '%d' out of range '%d'", Position, HistoryLength),
+			Code:    err.CodeOutOfRange,
 		}
 	}
 
-	s.OnNewItem(*s.history[Position])
+	s.historyLock.RLock()
+	item := *s.history[Position]
+	s.historyLock.RUnlock()
+	s.OnNewItem(item)
 
 	defer func() {
-		s.pauseListen <- false
+		s.pause(false)
+		s.historyLock.Lock()
 		s.history = s.history[:Position+1]
+		s.historyInsertedAt = s.historyInsertedAt[:Position+1]
+		s.historyLock.Unlock()
 		s.waitForResumeAtPositionCompletion.Done()
 	}()
 	return nil
@@ -156,22 +212,38 @@ func (s *Stream[T]) ResumeAtHistoryPosition(Position int) error {
 // Pass a NewItem into the stream
 // Note: The NewItem will only be processed if the stream is currently listened to.
 //
-// New Item will always be added to the history.
+// New Item will always be added to the history. Items providing a WithSequence method, like event.Event, are assigned
+// the next sequence number of the stream, starting at 1. Items providing an IsExpired method are dropped if they expired
+// before they could be processed.
 func (s *Stream[T]) Add(NewItem T) {
-	if s.isListenedTo {
-		s.sink <- NewItem
+	s.historyLock.Lock()
+	s.sequence++
+	if sequenced, ok := any(NewItem).(interface{ WithSequence(Sequence uint64) T }); ok {
+		NewItem = sequenced.WithSequence(s.sequence)
+	}
+	if !s.noHistory {
+		s.history = append(s.history, &NewItem)
+		s.historyInsertedAt = append(s.historyInsertedAt, s.now())
+		s.applyRetention()
 	}
+	s.historyLock.Unlock()
 
-	if s.noHistory {
+	if !s.isListenedTo {
 		return
 	}
-
-	if len(s.history) >= s.MaxHistorySize {
-		s.history = s.history[1:s.MaxHistorySize]
-		s.history = append(s.history, &NewItem)
-	} else {
-		s.history = append(s.history, &NewItem)
+	if s.usesLanes {
+		s.enqueue(NewItem)
+		return
+	}
+	if s.scheduler != nil {
+		s.scheduler.Schedule(func() {
+			if s.isListenedTo && !s.expired(NewItem) {
+				s.OnNewItem(NewItem)
+			}
+		})
+		return
 	}
+	s.sink <- NewItem
 }
 
 // Will close all channels used by the stream, in addition to that will also stop listening to stream.
@@ -186,6 +258,7 @@ func (s *Stream[_]) Dispose() {
 
 	defer func() {
 		close(s.sink)
+		close(s.ready)
 		close(s.pauseListen)
 		close(s.stopListen)
 		s.wasDisposed = true
@@ -2,11 +2,12 @@ package stream
 
 import (
 	"errors"
-	"fmt"
 	"reflect"
 	"sync"
 	"testing"
 	"time"
+
+	blocError "github.com/hijgo/go-bloc/error"
 )
 
 func TestCreateStream(t *testing.T) {
@@ -235,8 +236,8 @@ func TestStream_ListenShouldReturnErrorWhenAlreadyDisposed(t *testing.T) {
 	err := s.Listen()
 	if err == nil {
 		t.Errorf("Expected Listen To Return Error When Already Disposed")
-	} else if wantedErr := errors.New("stream was disposed"); err.Error() != wantedErr.Error() {
-		t.Errorf("Expected Listen To Return Error With Message '%s ' Actual '%s'", wantedErr.Error(), err.Error())
+	} else if wantedErr := blocError.ErrDisposed; !errors.Is(err, wantedErr) {
+		t.Errorf("Expected Listen To Return Error '%s' Actual '%s'", wantedErr.Error(), err.Error())
 	}
 }
 
@@ -251,8 +252,8 @@ func TestStream_ListenShouldReturnErrorWhenAlreadyListenedTo(t *testing.T) {
 	err = s.Listen()
 	if err == nil {
 		t.Errorf("Expected Listen To Return Error When Already Listend To")
-	} else if wantedErr := errors.New("stream already listened to"); err.Error() != wantedErr.Error() {
-		t.Errorf("Expected Listen To Return Error With Message '%s ' Actual '%s'", wantedErr.Error(), err.Error())
+	} else if wantedErr := blocError.ErrAlreadyListening; !errors.Is(err, wantedErr) {
+		t.Errorf("Expected Listen To Return Error '%s' Actual '%s'", wantedErr.Error(), err.Error())
 	}
 
 	defer s.Dispose()
@@ -269,16 +270,16 @@ func TestStream_ResumeAtHistoryPositionShouldReturnErrorWhenPositionNotInRange(t
 	s.waitForResumeAtPositionCompletion.Wait()
 	if err == nil {
 		t.Errorf("Expected ResumeAtHistoryPosition To Return Error When Position Out Of Range")
-	} else if wantedErr := fmt.Errorf("position '%d' out of range '%d'", 0, len(s.history)); err.Error() != wantedErr.Error() {
-		t.Errorf("Expected Listen To Return Error With Message '%s ' Actual '%s'", wantedErr.Error(), err.Error())
+	} else if wantedErr := blocError.ErrOutOfRange; !errors.Is(err, wantedErr) {
+		t.Errorf("Expected Listen To Return Error '%s' Actual '%s'", wantedErr.Error(), err.Error())
 	}
 
 	err = s.ResumeAtHistoryPosition(-2)
 	s.waitForResumeAtPositionCompletion.Wait()
 	if err == nil {
 		t.Errorf("Expected ResumeAtHistoryPosition To Return Error When Position Out Of Range")
-	} else if wantedErr := fmt.Errorf("position '%d' out of range '%d'", -2, len(s.history)); err.Error() != wantedErr.Error() {
-		t.Errorf("Expected Listen To Return Error With Message '%s ' Actual '%s'", wantedErr.Error(), err.Error())
+	} else if wantedErr := blocError.ErrOutOfRange; !errors.Is(err, wantedErr) {
+		t.Errorf("Expected Listen To Return Error '%s' Actual '%s'", wantedErr.Error(), err.Error())
 	}
 
 	defer s.Dispose()
@@ -290,8 +291,8 @@ func TestStream_StopListenShouldReturnErrorWhenAlreadyStopped(t *testing.T) {
 	err := s.StopListen()
 	if err == nil {
 		t.Errorf("Expected StopListen To Return Error When Not Listened To")
-	} else if wantedErr := errors.New("stream isn't listened to"); err.Error() != wantedErr.Error() {
-		t.Errorf("Expected StopListen To Return Error With Message '%s ' Actual '%s'", wantedErr.Error(), err.Error())
+	} else if wantedErr := blocError.ErrNotListening; !errors.Is(err, wantedErr) {
+		t.Errorf("Expected StopListen To Return Error '%s' Actual '%s'", wantedErr.Error(), err.Error())
 	}
 
 	err = s.Listen()
@@ -307,7 +308,7 @@ func TestStream_StopListenShouldReturnErrorWhenAlreadyStopped(t *testing.T) {
 	err = s.StopListen()
 	if err == nil {
 		t.Errorf("Expected StopListen To Return Error When Not Listened To")
-	} else if wantedErr := errors.New("stream isn't listened to"); err.Error() != wantedErr.Error() {
-		t.Errorf("Expected StopListen To Return Error With Message '%s ' Actual '%s'", wantedErr.Error(), err.Error())
+	} else if wantedErr := blocError.ErrNotListening; !errors.Is(err, wantedErr) {
+		t.Errorf("Expected StopListen To Return Error '%s' Actual '%s'", wantedErr.Error(), err.Error())
 	}
 }